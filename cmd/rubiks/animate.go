@@ -0,0 +1,188 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/michaellavery-grp/rubiks-cube-solver/cube"
+	"github.com/michaellavery-grp/rubiks-cube-solver/render"
+)
+
+// Moves are drawn turning in the 3D views: the cube takes the move at once,
+// so everything else sees the new state, while the view draws the old one
+// with the move's layers part way round, a frame per tick.
+
+// keyMoves are the moves the letter keys make, lower case clockwise
+var keyMoves = map[string]cube.Move{
+	"r": cube.R, "R": cube.Ri, "l": cube.L, "L": cube.Li,
+	"u": cube.U, "U": cube.Ui, "d": cube.D, "D": cube.Di,
+	"f": cube.F, "F": cube.Fi, "b": cube.B, "B": cube.Bi,
+}
+
+const (
+	// defaultTurnTime is how long a quarter turn takes to draw
+	defaultTurnTime = 250 * time.Millisecond
+	// frameTime is how often a turning move is redrawn
+	frameTime = time.Second / 30
+	// playPause is the pause between moves when playing a solution
+	playPause = 300 * time.Millisecond
+	// Turn times the speed keys step between
+	minTurnTime = 50 * time.Millisecond
+	maxTurnTime = 2 * time.Second
+)
+
+// turning is a move being drawn part way through
+type turning struct {
+	before   *cube.Cube // the cube as it was before the move
+	move     cube.Move
+	progress float64 // from 0 to 1
+	id       int
+}
+
+// frameMsg asks for the next frame of the turn with the same id; a turn
+// that has been replaced or skipped ignores it
+type frameMsg int
+
+// playMsg asks for the next move of a solution that is playing, unless
+// another move has been made since it was sent
+type playMsg int
+
+// turnCube applies move to the cube and, if animation is on, starts drawing
+// it turning in the 3D views. A turn still being drawn finishes at once.
+func (m *model) turnCube(move cube.Move) tea.Cmd {
+	before := m.cube.Clone()
+	m.cube.ApplyMove(move)
+	m.turnID++
+	m.turn = nil
+	if m.turnTime <= 0 || views[m.view].flat {
+		return m.playNext()
+	}
+	m.turn = &turning{before: before, move: move, id: m.turnID}
+	return nextFrame(m.turnID)
+}
+
+// nextFrame asks for the next frame of the turn id
+func nextFrame(id int) tea.Cmd {
+	return tea.Tick(frameTime, func(time.Time) tea.Msg { return frameMsg(id) })
+}
+
+// frame moves the turn being drawn on by a frame
+func (m *model) frame(id int) tea.Cmd {
+	if m.turn == nil || m.turn.id != id {
+		return nil
+	}
+	// Half turns take a little longer than quarter turns
+	duration := m.turnTime
+	if _, _, quarters, _ := m.turn.move.Layers(); quarters == 2 {
+		duration = duration * 3 / 2
+	}
+	m.turn.progress += float64(frameTime) / float64(duration)
+	if m.turn.progress >= 1 {
+		m.turn = nil
+		return m.playNext()
+	}
+	return nextFrame(id)
+}
+
+// skipTurn finishes the turn being drawn at once
+func (m *model) skipTurn() tea.Cmd {
+	if m.turn == nil {
+		return nil
+	}
+	m.turn = nil
+	m.turnID++
+	return m.playNext()
+}
+
+// twist returns the cube the 3D view draws and how far its turning layers
+// have got, eased in and out
+func (m model) twist() (*cube.Cube, render.Twist) {
+	if m.turn == nil {
+		return m.cube, render.Twist{}
+	}
+	p := m.turn.progress
+	return m.turn.before, render.Twist{Move: m.turn.move, Progress: p * p * (3 - 2*p)}
+}
+
+// nextMove makes the next move of the solution
+func (m *model) nextMove() tea.Cmd {
+	if m.mode != "solve" || m.currentMove >= len(m.solution) {
+		return nil
+	}
+	move := m.solution[m.currentMove]
+	m.moveHistory = append(m.moveHistory, move)
+	m.currentMove++
+	m.message = fmt.Sprintf("Move %d/%d: %s", m.currentMove, len(m.solution), move)
+	if stage, ok := stageOf(m.stages, m.currentMove-1); ok {
+		m.message += fmt.Sprintf(" (%s: %s)", stage.Name, stage.Description)
+	}
+	return m.turnCube(move)
+}
+
+// playNext waits, then asks for the next move of a playing solution. It
+// stops playing at the end.
+func (m *model) playNext() tea.Cmd {
+	if !m.playing {
+		return nil
+	}
+	if m.mode != "solve" || m.currentMove >= len(m.solution) {
+		m.playing = false
+		return nil
+	}
+	id := m.turnID
+	return tea.Tick(playPause, func(time.Time) tea.Msg { return playMsg(id) })
+}
+
+// play makes the next move of a playing solution, if nothing has moved the
+// cube since it was asked for
+func (m *model) play(id int) tea.Cmd {
+	if !m.playing || id != m.turnID {
+		return nil
+	}
+	return m.nextMove()
+}
+
+// togglePlay starts or stops playing the solution
+func (m *model) togglePlay() tea.Cmd {
+	if m.playing {
+		m.playing = false
+		m.message = "Paused. Press 'p' to play on, SPACE for one move"
+		return nil
+	}
+	if m.mode != "solve" || m.currentMove >= len(m.solution) {
+		m.message = "Nothing to play - press 's' to solve first"
+		return nil
+	}
+	m.playing = true
+	if m.turn != nil {
+		return nil // plays on when the turn being drawn ends
+	}
+	return m.nextMove()
+}
+
+// changeSpeed makes turns take factor times as long, within limits, turning
+// animation on if it was off
+func (m *model) changeSpeed(factor float64) {
+	switch {
+	case m.turnTime <= 0:
+		m.turnTime = defaultTurnTime
+	default:
+		m.turnTime = time.Duration(float64(m.turnTime) * factor)
+	}
+	m.turnTime = max(minTurnTime, min(maxTurnTime, m.turnTime))
+	m.message = fmt.Sprintf("Turns take %v", m.turnTime)
+}
+
+// toggleAnimation turns animation off, or back on at the default speed
+func (m *model) toggleAnimation() tea.Cmd {
+	if m.turnTime > 0 {
+		m.turnTime = 0
+		m.message = "Animation off"
+		return m.skipTurn()
+	}
+	m.turnTime = defaultTurnTime
+	m.message = fmt.Sprintf("Animation on: turns take %v", m.turnTime)
+	return nil
+}
@@ -0,0 +1,429 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/michaellavery-grp/rubiks-cube-solver/cube"
+	"github.com/michaellavery-grp/rubiks-cube-solver/render"
+)
+
+// commands are the non-interactive subcommands, e.g. `rubiks solve R U R' U'`
+var commands = map[string]func(args []string) error{
+	"algs":     algsCommand,
+	"export":   exportCommand,
+	"gif":      gifCommand,
+	"scramble": scrambleCommand,
+	"solve":    solveCommand,
+	"solvers":  solversCommand,
+}
+
+// solveCommand solves the cube reached by applying a scramble to a solved cube
+func solveCommand(args []string) error {
+	fs := flag.NewFlagSet("solve", flag.ExitOnError)
+	solvers := fs.String("solver", strings.Join(cube.DefaultChain, ","), solverFlagUsage())
+	timeout := fs.Duration("timeout", 30*time.Second, "give up after this long")
+	metricName := fs.String("metric", "HTM", "move count metric: HTM, QTM, STM or ETM (the optimal solver minimises HTM or QTM)")
+	memory := fs.Int64("memory", cube.DefaultMemoryBudget>>20, "megabytes the optimal solver may spend on its tables")
+	moveSet := fs.String("moves", "", "solve using only these moves, e.g. \"<R,U>\" (search-based solvers)")
+	depth := fs.Int("depth", 20, "longest solution to look for with -moves")
+	count := fs.Int("n", 1, "number of solutions to find with -moves, shortest first (-1 for all within -depth)")
+	costs := fs.String("costs", "", "cost model file: break ties between equally short solutions by how quick they are to perform (default "+cube.CostModelPath()+" if it exists)")
+	goalFlag := fs.String("goal", "", "solve only this part in the fewest moves, e.g. \"cross\", \"cross,DFR,FR\", \"first-block\" or \"U-cross\"")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: rubiks solve [flags] <scramble>")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	metric, err := cube.ParseMetric(*metricName)
+	if err != nil {
+		return err
+	}
+	scramble, err := cube.ParseMoves(strings.Join(fs.Args(), " "))
+	if err != nil {
+		return fmt.Errorf("scramble: %w", err)
+	}
+	var allowed []cube.Move
+	if *moveSet != "" {
+		if allowed, err = cube.ParseMoveSet(*moveSet); err != nil {
+			return err
+		}
+	}
+	cost, err := costModel(*costs, false)
+	if err != nil {
+		return err
+	}
+	c := cube.NewCube()
+	c.ApplyMoves(scramble)
+	if *goalFlag != "" {
+		return solveGoal(c, *goalFlag, allowed, *depth, *count, metric, *timeout)
+	}
+
+	opts := cube.Options{
+		History:      scramble,
+		Metric:       metric,
+		MemoryBudget: *memory << 20,
+		Moves:        allowed,
+		MaxDepth:     *depth,
+		MaxSolutions: *count,
+		Cost:         cost,
+		Progress:     func(status string) { fmt.Fprintln(os.Stderr, status) },
+	}
+	chain, err := cube.NewChain(splitList(*solvers), opts)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	solution, err := chain.Solve(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s (%d %s, %s)\n", cube.FormatMoves(solution.Moves), metric.Count(solution.Moves), metric, solution.Solver)
+	for _, stage := range solution.Stages {
+		if stage.Skipped {
+			fmt.Printf("  %-24s skipped\n", stage.Name+":")
+			continue
+		}
+		fmt.Printf("  %-24s %s (%d %s)\n", stage.Name+":", cube.FormatMoves(stage.Moves), metric.Count(stage.Moves), metric)
+	}
+	for _, moves := range solution.Alternatives {
+		fmt.Printf("%s (%d %s)\n", cube.FormatMoves(moves), metric.Count(moves), metric)
+	}
+	return nil
+}
+
+// solveGoal prints the shortest ways to reach part of a solved cube
+func solveGoal(c *cube.Cube, goalFlag string, moves []cube.Move, depth, count int, metric cube.Metric, timeout time.Duration) error {
+	goal, err := cube.ParseGoal(goalFlag)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	solutions, err := cube.SolveGoal(ctx, c, goal, moves, depth, count)
+	if err != nil {
+		return err
+	}
+	for _, moves := range solutions {
+		fmt.Printf("%s (%d %s)\n", cube.FormatMoves(moves), metric.Count(moves), metric)
+	}
+	return nil
+}
+
+// algsCommand prints algorithms for a case, one per line in notation that
+// can be pasted back in, shortest and easiest first
+func algsCommand(args []string) error {
+	fs := flag.NewFlagSet("algs", flag.ExitOnError)
+	state := fs.String("state", "", "the case as 54 facelets in Kociemba order (URFDLB), instead of a setup algorithm")
+	moveSet := fs.String("moves", "", "moves the algorithms may use, e.g. \"<R,U,F>\" (default every face turn)")
+	goalFlag := fs.String("solve", "all", "what the algorithms must solve: pieces, groups or stickers, e.g. \"F2L,corners\", \"cross,DFR,FR\" or \"F2L,U-cross\"")
+	aufFlag := fs.Bool("auf", false, "accept algorithms that leave a turn of U")
+	count := fs.Int("n", 10, "number of algorithms")
+	costs := fs.String("costs", "", "cost model file ranking algorithms of the same length (default "+cube.CostModelPath()+" if it exists)")
+	byCost := fs.Bool("by-cost", false, "rank by cost alone, so a longer but quicker algorithm can come first")
+	depth := fs.Int("depth", 14, "longest algorithm to look for")
+	timeout := fs.Duration("timeout", 2*time.Minute, "give up after this long, printing what was found")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: rubiks algs [flags] <setup algorithm>")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	var c *cube.Cube
+	var err error
+	if *state != "" {
+		if fs.NArg() > 0 {
+			return fmt.Errorf("give either -state or a setup algorithm, not both")
+		}
+		if c, err = cube.ParseFacelets(*state); err != nil {
+			return err
+		}
+	} else {
+		setup, err := cube.ParseMoves(strings.Join(fs.Args(), " "))
+		if err != nil {
+			return fmt.Errorf("setup: %w", err)
+		}
+		c = cube.NewCube()
+		c.ApplyMoves(setup)
+	}
+	opts := cube.GenerateOptions{MaxDepth: *depth, Count: *count, ByCost: *byCost}
+	if opts.Cost, err = costModel(*costs, true); err != nil {
+		return err
+	}
+	if *moveSet != "" {
+		if opts.Moves, err = cube.ParseMoveSet(*moveSet); err != nil {
+			return err
+		}
+	}
+	if opts.Goal, err = cube.ParseGoal(*goalFlag); err != nil {
+		return err
+	}
+	opts.Goal.AUF = opts.Goal.AUF || *aufFlag
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	algs, err := cube.GenerateAlgs(ctx, c, opts)
+	if err != nil {
+		return err
+	}
+	switch {
+	case len(algs) == 0:
+		return fmt.Errorf("no algorithms within %d moves", *depth)
+	case len(algs[0]) == 0:
+		return fmt.Errorf("the case is already solved")
+	}
+	for _, alg := range algs {
+		fmt.Printf("%s // %d moves, cost %.1f\n", cube.FormatMoves(alg), len(alg), opts.Cost.Cost(alg))
+	}
+	return nil
+}
+
+// exportCommand draws the cube reached by a setup algorithm, or a given
+// state, as SVG or PNG
+func exportCommand(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	state := fs.String("state", "", "the cube as 54 facelets in Kociemba order (URFDLB), instead of a setup algorithm")
+	caseOf := fs.Bool("case", false, "the moves are an algorithm: draw the case it solves")
+	layoutName := fs.String("layout", "3d", "net, 3d or top (the top face with the top layer's sides, for OLL and PLL)")
+	output := fs.String("o", "", "file to write, SVG or PNG by its extension (default SVG to standard output)")
+	format := fs.String("format", "", "svg or png, whatever the file is called")
+	width := fs.Int("width", 300, "width of the image in pixels")
+	maskFlag := fs.String("mask", "", "color only these pieces or stickers, the rest grey, e.g. \"F2L\" or \"LL\" (see solve -goal)")
+	oll := fs.Bool("oll", false, "color only stickers of the top center's color, as OLL diagrams do")
+	arrows := fs.Bool("arrows", false, "in the top layout, draw arrows to where each top layer piece goes, as PLL diagrams do")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: rubiks export [flags] <setup algorithm>")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	var c *cube.Cube
+	var err error
+	if *state != "" {
+		if fs.NArg() > 0 {
+			return fmt.Errorf("give either -state or a setup algorithm, not both")
+		}
+		if c, err = cube.ParseFacelets(*state); err != nil {
+			return err
+		}
+	} else {
+		moves, err := cube.ParseMoves(strings.Join(fs.Args(), " "))
+		if err != nil {
+			return fmt.Errorf("setup: %w", err)
+		}
+		if *caseOf {
+			moves = cube.Invert(moves)
+		}
+		c = cube.NewCube()
+		c.ApplyMoves(moves)
+	}
+	opts := render.DiagramOptions{Width: *width, Arrows: *arrows}
+	if opts.Layout, err = render.ParseLayout(*layoutName); err != nil {
+		return err
+	}
+	var goal cube.Goal
+	if *maskFlag != "" {
+		if goal, err = cube.ParseGoal(*maskFlag); err != nil {
+			return err
+		}
+	}
+	if *maskFlag != "" || *oll {
+		top := c.Sticker(cube.Up, 4)
+		opts.Mask = func(s cube.Sticker, color cube.Color) bool {
+			return (*maskFlag == "" || goal.Covers(s)) && (!*oll || color == top)
+		}
+	}
+
+	write := render.WriteSVG
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(*output)), ".")
+	}
+	switch *format {
+	case "png":
+		write = render.WritePNG
+	case "", "svg":
+	default:
+		return fmt.Errorf("unknown format %q (want svg or png)", *format)
+	}
+	if *output == "" {
+		return write(os.Stdout, c, opts)
+	}
+	f, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+	if err := write(f, c, opts); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// gifCommand writes an animated GIF of a scramble's solution, solving it
+// first unless the solution is given
+func gifCommand(args []string) error {
+	fs := flag.NewFlagSet("gif", flag.ExitOnError)
+	output := fs.String("o", "solution.gif", "file to write")
+	solutionFlag := fs.String("solution", "", "the moves to animate (default: solve the scramble)")
+	solvers := fs.String("solver", strings.Join(cube.DefaultChain, ","), solverFlagUsage())
+	timeout := fs.Duration("timeout", 30*time.Second, "give up solving after this long")
+	layoutName := fs.String("layout", "3d", "net, 3d or top")
+	width := fs.Int("width", 300, "width of the image in pixels")
+	frames := fs.Int("frames", 6, "frames each move takes to turn in the 3d layout")
+	moveTime := fs.Duration("move-time", 800*time.Millisecond, "how long each move shows for")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: rubiks gif [flags] <scramble>")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	scramble, err := cube.ParseMoves(strings.Join(fs.Args(), " "))
+	if err != nil {
+		return fmt.Errorf("scramble: %w", err)
+	}
+	c := cube.NewCube()
+	c.ApplyMoves(scramble)
+
+	var moves []cube.Move
+	if *solutionFlag != "" {
+		if moves, err = cube.ParseMoves(*solutionFlag); err != nil {
+			return fmt.Errorf("solution: %w", err)
+		}
+	} else {
+		chain, err := cube.NewChain(splitList(*solvers), cube.Options{
+			History:  scramble,
+			Progress: func(status string) { fmt.Fprintln(os.Stderr, status) },
+		})
+		if err != nil {
+			return err
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+		defer cancel()
+		solution, err := chain.Solve(ctx, c)
+		if err != nil {
+			return err
+		}
+		moves = solution.Moves
+		fmt.Fprintf(os.Stderr, "%s (%d HTM, %s)\n", cube.FormatMoves(moves), cube.HTM.Count(moves), solution.Solver)
+	}
+
+	opts := render.GIFOptions{Frames: *frames, MoveTime: *moveTime}
+	opts.Width = *width
+	if opts.Layout, err = render.ParseLayout(*layoutName); err != nil {
+		return err
+	}
+	f, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+	if err := render.WriteGIF(f, c, moves, opts); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// scrambleCommand prints scrambles, one per line
+func scrambleCommand(args []string) error {
+	fs := flag.NewFlagSet("scramble", flag.ExitOnError)
+	count := fs.Int("n", 1, "number of scrambles")
+	seed := fs.Uint64("seed", 0, "random seed, for reproducible scrambles (0 picks one at random)")
+	moves := fs.Int("moves", 0, "use this many random face turns instead of a random-state scramble")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: rubiks scramble [flags]")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	s := cube.NewScrambler(scrambleSeed(*seed))
+	for i := 0; i < *count; i++ {
+		if *moves > 0 {
+			fmt.Println(cube.FormatMoves(s.RandomMoves(*moves)))
+			continue
+		}
+		scramble, err := s.RandomState()
+		if err != nil {
+			return err
+		}
+		fmt.Println(cube.FormatMoves(scramble))
+	}
+	return nil
+}
+
+// scrambleSeed returns seed, or a random one if it is zero
+func scrambleSeed(seed uint64) uint64 {
+	if seed == 0 {
+		return rand.Uint64()
+	}
+	return seed
+}
+
+// solversCommand lists the registered solvers and what they offer
+func solversCommand(args []string) error {
+	names := cube.Solvers()
+	// The names make a column as wide as the longest
+	width := 0
+	for _, name := range names {
+		width = max(width, len(name))
+	}
+	for _, name := range names {
+		s, err := cube.NewSolver(name, cube.Options{})
+		if err != nil {
+			return err
+		}
+		caps := s.Capabilities()
+		var traits []string
+		if caps.Optimal {
+			traits = append(traits, "optimal")
+		}
+		if caps.HumanReadable {
+			traits = append(traits, "human-readable")
+		}
+		if caps.StepAnnotations {
+			traits = append(traits, "step annotations")
+		}
+		if caps.MoveSets {
+			traits = append(traits, "move sets")
+		}
+		fmt.Printf("%-*s  %s\n", width, name, strings.Join(traits, ", "))
+	}
+	return nil
+}
+
+// costModel loads the cost model in path or, if path is empty, in the
+// user's config file. Without either it returns the default model, or nil
+// if fallback isn't set.
+func costModel(path string, fallback bool) (*cube.CostModel, error) {
+	if path == "" && !fallback {
+		if _, err := os.Stat(cube.CostModelPath()); err != nil {
+			return nil, nil
+		}
+	}
+	return cube.LoadCostModel(path)
+}
+
+func solverFlagUsage() string {
+	return "comma separated solver chain, tried in order (" + strings.Join(cube.Solvers(), ", ") + ")"
+}
+
+// splitList splits a comma separated flag value, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
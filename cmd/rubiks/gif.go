@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/michaellavery-grp/rubiks-cube-solver/cube"
+	"github.com/michaellavery-grp/rubiks-cube-solver/render"
+)
+
+// gifFile is where 'g' saves the solution's animation
+const gifFile = "solution.gif"
+
+// gifSavedMsg reports how saving the animation went
+type gifSavedMsg struct {
+	moves int
+	err   error
+}
+
+// saveGIF writes an animated GIF of the whole solution, from the cube it
+// solves, in the background. The 3D views animate from the camera's
+// viewpoint; the flat view steps through the net.
+func (m *model) saveGIF() tea.Cmd {
+	if m.mode != "solve" || len(m.solution) == 0 {
+		m.message = "Nothing to save - press 's' to solve first"
+		return nil
+	}
+	start := m.cube.Clone()
+	start.ApplyMoves(cube.Invert(m.solution[:m.currentMove]))
+	moves := m.solution
+	opts := render.GIFOptions{}
+	opts.Layout, opts.Camera = render.Layout3D, m.camera
+	if views[m.view].flat {
+		opts.Layout = render.LayoutNet
+	}
+	m.message = "Saving " + gifFile + "…"
+	return func() tea.Msg {
+		f, err := os.Create(gifFile)
+		if err != nil {
+			return gifSavedMsg{err: err}
+		}
+		if err := render.WriteGIF(f, start, moves, opts); err != nil {
+			f.Close()
+			return gifSavedMsg{err: err}
+		}
+		return gifSavedMsg{len(moves), f.Close()}
+	}
+}
+
+// gifSaved reports the saved animation
+func (m *model) gifSaved(msg gifSavedMsg) {
+	if msg.err != nil {
+		m.message = "Couldn't save the animation: " + msg.err.Error()
+		return
+	}
+	m.message = fmt.Sprintf("Saved the %d move solution to %s", msg.moves, gifFile)
+}
@@ -0,0 +1,271 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/michaellavery-grp/rubiks-cube-solver/cube"
+)
+
+// The view fits the terminal: the cube is drawn as large as there is room
+// for, with panels for the solution, the move history and some stats beside
+// it, or below it on narrow terminals. Terminals too small for that get the
+// net and the status message alone.
+
+// Terminal size assumed until the first tea.WindowSizeMsg arrives
+const (
+	defaultWidth  = 120
+	defaultHeight = 40
+)
+
+const (
+	// minViewHeight is the smallest 3D view worth drawing; below it the
+	// cube is shown as a net
+	minViewHeight = 10
+	// Width of the panels beside the cube
+	minPanelWidth = 24
+	maxPanelWidth = 40
+	// panelGap separates the cube from the panels beside it
+	panelGap = 2
+	// maxPanelLines is how many lines the panels take below the cube
+	maxPanelLines = 4
+	// maxDiagnostics is how many validation problems are listed
+	maxDiagnostics = 3
+)
+
+// Sizes of the flat view's net in terminal cells: large stickers are three
+// cells wide with gaps between faces, compact ones a single cell
+const (
+	netWidth, netHeight               = 29, 11
+	compactNetWidth, compactNetHeight = 11, 9
+)
+
+// drawing is a way of drawing the cube, and its size
+type drawing struct {
+	width, height int
+	net           bool // the flat net, even in a 3D view
+	compact       bool // a net of one-cell stickers
+}
+
+// layout is how the view fills the terminal
+type layout struct {
+	width    int
+	controls []string // the key help, wrapped to the width
+	cube     drawing
+	side     bool // panels beside the cube, rather than below
+	panel    struct{ width, lines int }
+	minimal  bool // too small for anything but the net and the message
+}
+
+// controls are the keys, as help
+var controls = []string{
+	"[r/R] Right", "[l/L] Left", "[u/U] Up", "[d/D] Down", "[f/F] Front", "[b/B] Back",
+	"[s] Solve", "[n] New Scramble", "[i] Input", "[t] Toggle View", "[←↑↓→] Turn View",
+	"[e] Edge Orientation", "[q] Quit", "[Space] Next", "[p] Play/Pause", "[Enter] Undo",
+	"[a] Animation", "[+/-] Speed", "[Esc] Skip Turn", "[g] Save GIF",
+}
+
+// size returns the terminal's size in cells
+func (m model) size() (width, height int) {
+	if m.width <= 0 || m.height <= 0 {
+		return defaultWidth, defaultHeight
+	}
+	return m.width, m.height
+}
+
+// layout fits the view to the terminal: the largest drawing of the cube
+// with the panels beside it, or failing that below it
+func (m model) layout() layout {
+	width, height := m.size()
+	l := layout{width: width, controls: wrapWords(controls, width)}
+	// Title and blank line, the blank lines around the controls, and the
+	// message
+	room := height - 5 - len(l.controls) - min(len(m.diagnostics), maxDiagnostics+1)
+	for _, d := range m.drawings(room) {
+		if panel := width - d.width - panelGap; panel >= minPanelWidth {
+			l.cube, l.side = d, true
+			l.panel.width, l.panel.lines = min(panel, maxPanelWidth), room
+			return l
+		}
+		if d.width <= width && d.height < room {
+			l.cube = d
+			l.panel.width, l.panel.lines = width, min(room-d.height, maxPanelLines)
+			return l
+		}
+	}
+	return layout{width: width, cube: drawing{compactNetWidth, compactNetHeight, true, true}, minimal: true}
+}
+
+// drawings returns the ways to draw the cube in the view no taller than
+// height, largest first
+func (m model) drawings(height int) []drawing {
+	var out []drawing
+	if !views[m.view].flat {
+		for h := min(viewHeight, height); h >= minViewHeight; h -= 2 {
+			out = append(out, drawing{width: h * viewWidth / viewHeight, height: h})
+		}
+	}
+	if height >= netHeight {
+		out = append(out, drawing{netWidth, netHeight, true, false})
+	}
+	if height >= compactNetHeight {
+		out = append(out, drawing{compactNetWidth, compactNetHeight, true, true})
+	}
+	return out
+}
+
+// renderCube draws the cube as the layout says
+func (m model) renderCube(d drawing) string {
+	if d.net {
+		return m.renderFlatCube(d.compact)
+	}
+	return m.render3DCube(d.width, d.height)
+}
+
+// renderPanels draws the solution, the move history and the stats to the
+// layout's panel size: beside the cube each has a heading, below it each
+// gets a line
+func (m model) renderPanels(l layout) string {
+	heading := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("205"))
+	history := lipgloss.NewStyle().Foreground(lipgloss.Color("14"))
+	solution := lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
+	next := solution.Reverse(true)
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	solving := m.mode == "solve" && len(m.solution) > 0
+	speed := "animation off"
+	if m.turnTime > 0 {
+		speed = fmt.Sprintf("turns take %v", m.turnTime)
+	}
+	stats := []string{
+		views[m.view].name,
+		"Solvers: " + strings.Join(m.solvers, " → "),
+		fmt.Sprintf("Moves: %d, %s", len(m.moveHistory), speed),
+	}
+
+	var lines []string
+	if !l.side {
+		// A line each, solution first, then as much as fits
+		if solving {
+			label := fmt.Sprintf("Solution %d/%d: ", m.currentMove, len(m.solution))
+			row := moveLines(m.solution, l.panel.width-len(label), 1, m.currentMove, solution, next)
+			lines = append(lines, heading.Render(label)+strings.Join(row, ""))
+		}
+		if len(m.moveHistory) > 0 {
+			row := moveLines(m.moveHistory, l.panel.width-len("Moves: "), 1, len(m.moveHistory)-1, history, history)
+			lines = append(lines, heading.Render("Moves: ")+strings.Join(row, ""))
+		}
+		lines = append(lines, dim.Render(strings.Join(stats, " · ")))
+		return strings.Join(lines[:min(len(lines), l.panel.lines)], "\n")
+	}
+
+	// Stats at the bottom; the solution and the history share the rest,
+	// each scrolled to its latest move
+	room := l.panel.lines - 1 - len(stats)
+	sections := 0
+	if solving {
+		sections++
+	}
+	if len(m.moveHistory) > 0 {
+		sections++
+	}
+	if room < 3*sections {
+		room, stats = l.panel.lines, nil
+	}
+	if solving {
+		share := (room - 2*sections) / sections
+		lines = append(lines, heading.Render(fmt.Sprintf("Solution %d/%d", m.currentMove, len(m.solution))))
+		lines = append(lines, moveLines(m.solution, l.panel.width, share, m.currentMove, solution, next)...)
+		lines = append(lines, "")
+		room -= len(lines)
+	}
+	if len(m.moveHistory) > 0 {
+		lines = append(lines, heading.Render(fmt.Sprintf("History (%d)", len(m.moveHistory))))
+		lines = append(lines, moveLines(m.moveHistory, l.panel.width, room-2, len(m.moveHistory)-1, history, history)...)
+		lines = append(lines, "")
+	}
+	if stats != nil {
+		lines = append(lines, heading.Render("Stats"))
+		for _, s := range stats {
+			lines = append(lines, dim.Render(s))
+		}
+	}
+	return lipgloss.NewStyle().MaxWidth(l.panel.width).Render(strings.Join(lines[:min(len(lines), l.panel.lines)], "\n"))
+}
+
+// moveLines wraps moves into lines of at most width cells and returns up to
+// n of them, scrolled to show move focus, which is drawn in its own style
+func moveLines(moves []cube.Move, width, n, focus int, style, focused lipgloss.Style) []string {
+	if n <= 0 || width <= 0 {
+		return nil
+	}
+	words := make([]string, len(moves))
+	for i, move := range moves {
+		words[i] = string(move)
+	}
+	// first[i] is the index of the first move on line i
+	first := []int{0}
+	at := 0
+	for i, w := range words {
+		if at > 0 && at+1+len(w) > width {
+			first = append(first, i)
+			at = 0
+		}
+		if at > 0 {
+			at++
+		}
+		at += len(w)
+	}
+	line := len(first) - 1
+	for line > 0 && first[line] > focus {
+		line--
+	}
+	// A line of moves before the focus, if there is room
+	start := max(0, min(line-1, len(first)-n))
+	end := min(len(first), start+n)
+
+	var out []string
+	for i := start; i < end; i++ {
+		last := len(words)
+		if i+1 < len(first) {
+			last = first[i+1]
+		}
+		var b strings.Builder
+		for j := first[i]; j < last; j++ {
+			if j > first[i] {
+				b.WriteString(style.Render(" "))
+			}
+			if j == focus {
+				b.WriteString(focused.Render(words[j]))
+			} else {
+				b.WriteString(style.Render(words[j]))
+			}
+		}
+		out = append(out, b.String())
+	}
+	return out
+}
+
+// wrapWords joins words into lines no wider than width, with two spaces
+// between words
+func wrapWords(words []string, width int) []string {
+	var lines []string
+	var line string
+	for _, w := range words {
+		switch {
+		case line == "":
+			line = w
+		case lipgloss.Width(line)+2+lipgloss.Width(w) > width:
+			lines = append(lines, line)
+			line = w
+		default:
+			line += "  " + w
+		}
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
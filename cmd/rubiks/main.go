@@ -0,0 +1,650 @@
+// Rubik's Cube - Isometric 3D ASCII solver using Bubble Tea
+// Features: 3D rendering, solving algorithms, move hints, custom input
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/michaellavery-grp/rubiks-cube-solver/cube"
+	"github.com/michaellavery-grp/rubiks-cube-solver/render"
+)
+
+// Model for Bubble Tea
+type model struct {
+	cube        *cube.Cube
+	solution    []cube.Move
+	stages      []cube.Stage // labelled parts of solution, if the solver gives them
+	currentMove int
+	mode        string // "view", "input", "solve"
+	inputStep   int    // inputTop, inputFront or inputStickers
+	inputTop    cube.Color
+	inputFace   cube.Face
+	inputPos    int
+	moveHistory []cube.Move
+	message     string
+	diagnostics []string        // problems found by cube.Validate, shown until fixed
+	view        int             // index in views
+	camera      render.Camera   // viewpoint of the 3D views
+	turn        *turning        // the move being drawn turning, if any
+	turnID      int             // counts moves, so stale frames are ignored
+	turnTime    time.Duration   // how long a quarter turn takes to draw, 0 to turn at once
+	playing     bool            // the solution plays itself, a move at a time
+	showEO      bool            // mark the edges that are bad on eoAxis
+	eoAxis      cube.Axis       // axis of the edge orientation shown
+	solvers     []string        // solver chain, tried in order
+	moves       []cube.Move     // if set, the only moves solutions may use
+	cost        *cube.CostModel // if set, breaks ties between equally short solutions
+	scrambler   *cube.Scrambler
+	scheme      cube.ColorScheme   // colors of the user's cube
+	solving     bool               // a solve is running in the background
+	cancelSolve context.CancelFunc // stops the running solve
+	solveEvents chan tea.Msg       // progress and result of the running solve
+	width       int                // size of the terminal, 0 until it is known
+	height      int
+}
+
+// solveProgressMsg is a status update from a running solver
+type solveProgressMsg string
+
+// solveDoneMsg carries the result of a background solve
+type solveDoneMsg struct {
+	solution cube.Solution
+	err      error
+}
+
+// Input mode first asks how the cube is held, then takes the stickers
+const (
+	inputTop = iota
+	inputFront
+	inputStickers
+)
+
+const colorKeys = "(1=W,2=R,3=B,4=O,5=G,6=Y)"
+
+func initialModel(solvers []string, moves []cube.Move, cost *cube.CostModel, seed uint64, scheme cube.ColorScheme, turnTime time.Duration) model {
+	m := model{
+		mode:        "view",
+		camera:      views[0].camera, // Start with 3D perspective view
+		turnTime:    turnTime,
+		currentMove: 0,
+		solvers:     solvers,
+		moves:       moves,
+		cost:        cost,
+		scrambler:   cube.NewScrambler(seed),
+		scheme:      scheme,
+	}
+	m.scramble() // Start with scrambled cube
+	m.message = "Scrambled cube - Press 's' to solve, 'n' for a new scramble, 't' to toggle view, arrows to turn it"
+	return m
+}
+
+// scramble replaces the cube with a new random-state scramble. The scramble
+// becomes the move history, so the reversal solver can undo it.
+func (m *model) scramble() {
+	moves, err := m.scrambler.RandomState()
+	if err != nil {
+		m.message = "Scramble failed: " + err.Error()
+		return
+	}
+	m.cube = cube.NewCubeScheme(m.scheme)
+	m.cube.ApplyMoves(moves)
+	m.turn, m.playing = nil, false
+	m.moveHistory = moves
+	m.mode = "view"
+	m.solution, m.stages, m.currentMove = nil, nil, 0
+	m.diagnostics = nil
+	m.message = "Scramble: " + cube.FormatMoves(moves)
+}
+
+func (m model) Init() tea.Cmd {
+	return nil
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case solveProgressMsg:
+		m.message = "Solving: " + string(msg) + " Press ESC to cancel"
+		return m, waitForSolve(m.solveEvents)
+
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		return m, nil
+
+	case frameMsg:
+		return m, m.frame(int(msg))
+
+	case playMsg:
+		return m, m.play(int(msg))
+
+	case gifSavedMsg:
+		m.gifSaved(msg)
+		return m, nil
+
+	case solveDoneMsg:
+		m.solving = false
+		m.cancelSolve()
+		m.solveDone(msg.solution, msg.err)
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.solving {
+			// Only quitting and cancelling make sense while a solve runs
+			switch msg.String() {
+			case "q", "ctrl+c":
+				m.cancelSolve()
+				return m, tea.Quit
+			case "esc":
+				m.cancelSolve()
+				m.message = "Cancelling…"
+			}
+			return m, nil
+		}
+
+		switch msg.String() {
+		case "q", "ctrl+c":
+			return m, tea.Quit
+
+		case "s":
+			// Solve mode
+			return m, m.solveCube()
+
+		case "n":
+			// New random-state scramble
+			m.scramble()
+
+		case "i":
+			// Input mode
+			m.mode = "input"
+			m.inputStep = inputTop
+			m.message = "Input Mode: which color is on top? " + colorKeys
+
+		case "v":
+			m.mode = "view"
+			m.message = "View Mode"
+
+		case "m":
+			// Cycle which solver is tried first
+			m.solvers = nextSolver(m.solvers)
+			m.message = "Solvers: " + strings.Join(m.solvers, " → ")
+
+		case "e":
+			// Cycle the edge orientation shown: off, then each axis
+			switch {
+			case !m.showEO:
+				m.showEO, m.eoAxis = true, cube.AxisFB
+			case m.eoAxis == cube.AxisUD:
+				m.showEO = false
+			default:
+				m.eoAxis++
+			}
+			if m.showEO {
+				m.message = fmt.Sprintf("Edge orientation (%s axis): %d bad edges marked *", m.eoAxis, len(m.cube.BadEdges(m.eoAxis)))
+			} else {
+				m.message = "Edge orientation hidden"
+			}
+
+		case "t":
+			// Cycle the views, each 3D one from its preset viewpoint
+			m.view = (m.view + 1) % len(views)
+			m.camera = views[m.view].camera
+			m.message = views[m.view].name
+
+		case " ":
+			// Next move in solution
+			m.playing = false
+			return m, m.nextMove()
+
+		case "p":
+			// Play or pause the solution
+			return m, m.togglePlay()
+
+		case "esc":
+			// Finish the turn being drawn
+			return m, m.skipTurn()
+
+		case "a":
+			return m, m.toggleAnimation()
+
+		case "g":
+			// Save the solution as an animated GIF
+			return m, m.saveGIF()
+
+		case "+", "=":
+			m.changeSpeed(0.5)
+		case "-":
+			m.changeSpeed(2)
+
+		case "enter":
+			// Undo last move
+			if len(m.moveHistory) > 0 {
+				m.playing = false
+				lastMove := m.moveHistory[len(m.moveHistory)-1]
+				m.moveHistory = m.moveHistory[:len(m.moveHistory)-1]
+				if m.currentMove > 0 {
+					m.currentMove--
+				}
+				m.message = fmt.Sprintf("Undid: %s", lastMove)
+				// Apply reverse move
+				return m, m.turnCube(lastMove.Inverse())
+			}
+
+		case "r", "R", "l", "L", "u", "U", "d", "D", "f", "F", "b", "B":
+			move := keyMoves[msg.String()]
+			m.moveHistory = append(m.moveHistory, move)
+			m.playing = false
+			m.message = string(move)
+			return m, m.turnCube(move)
+
+		// Input mode controls
+		case "1", "2", "3", "4", "5", "6":
+			if m.mode == "input" {
+				m.inputKey(cube.Color(msg.String()[0] - '1'))
+			}
+
+		case "up", "down", "left", "right":
+			if m.mode == "input" && m.inputStep == inputStickers {
+				m.moveCursor(msg.String())
+			} else {
+				m.turnView(msg.String())
+			}
+		}
+	}
+
+	return m, nil
+}
+
+// moveCursor moves the sticker being entered in input mode
+func (m *model) moveCursor(key string) {
+	switch {
+	case key == "up" && m.inputPos >= 3:
+		m.inputPos -= 3
+	case key == "down" && m.inputPos < 6:
+		m.inputPos += 3
+	case key == "left" && m.inputPos%3 > 0:
+		m.inputPos--
+	case key == "right" && m.inputPos%3 < 2:
+		m.inputPos++
+	}
+}
+
+// turnView moves the 3D view's camera around the cube: left and right go
+// round it, up and down over the top or under the bottom
+func (m *model) turnView(key string) {
+	if views[m.view].flat {
+		m.message = "The flat view shows every face - press 't' for a 3D view to turn"
+		return
+	}
+	switch key {
+	case "up":
+		m.camera = m.camera.Turn(0, cameraStep)
+	case "down":
+		m.camera = m.camera.Turn(0, -cameraStep)
+	case "left":
+		m.camera = m.camera.Turn(cameraStep, 0)
+	case "right":
+		m.camera = m.camera.Turn(-cameraStep, 0)
+	}
+	m.message = "Viewing " + faceNames(m.camera.Faces())
+}
+
+// inputKey handles a color key in input mode: first the color on top, then
+// the one in front, which sets up the centers, then each sticker in turn
+func (m *model) inputKey(color cube.Color) {
+	switch m.inputStep {
+	case inputTop:
+		m.inputTop = color
+		m.inputStep = inputFront
+		m.message = fmt.Sprintf("Input Mode: %s on top. Which color faces you? %s", color, colorKeys)
+
+	case inputFront:
+		c := cube.NewCubeScheme(m.scheme)
+		if _, err := c.Orient(m.inputTop, color); err != nil {
+			m.message = fmt.Sprintf("Input Mode: %v. Which color faces you? %s", err, colorKeys)
+			return
+		}
+		m.cube = c
+		m.turn, m.playing = nil, false
+		m.moveHistory = nil
+		m.solution, m.stages, m.currentMove = nil, nil, 0
+		m.diagnostics = nil
+		m.inputStep = inputStickers
+		m.inputFace, m.inputPos = 0, 0
+		m.message = "Input Mode: centers are set. Use 1-6 for colors " + colorKeys + ", arrows to navigate"
+
+	case inputStickers:
+		m.cube.SetSticker(m.inputFace, m.inputPos, color)
+		m.inputPos++
+		if m.inputPos == 4 {
+			m.inputPos++ // the center is already known
+		}
+		if m.inputPos >= 9 {
+			m.inputPos = 0
+			m.inputFace++
+			if m.inputFace >= 6 {
+				m.inputFace = 0
+				m.mode = "view"
+				if m.validate() {
+					m.message = "Input complete! Press 's' to solve"
+				} else {
+					m.message = "Input complete, but this cube can't be solved. Press 'i' to fix it"
+				}
+			}
+		}
+	}
+}
+
+// solveCube starts the model's solver chain, Kociemba first by default, in
+// the background. Progress and the result arrive as messages, so slow
+// solvers (e.g. optimal) don't freeze the UI and can be cancelled.
+func (m *model) solveCube() tea.Cmd {
+	if !m.validate() {
+		m.message = "Invalid cube - fix the stickers with 'i' before solving"
+		return nil
+	}
+	if m.cube.IsSolved() {
+		m.message = "Cube already solved. Try some moves (r, u, f, etc.) then press 's' to solve!"
+		return nil
+	}
+
+	events := make(chan tea.Msg)
+	opts := cube.Options{
+		History:  m.moveHistory,
+		Moves:    m.moves,
+		Cost:     m.cost,
+		Progress: func(status string) { events <- solveProgressMsg(status) },
+	}
+	chain, err := cube.NewChain(m.solvers, opts)
+	if err != nil {
+		m.message = err.Error()
+		return nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	c := m.cube.Clone()
+	go func() {
+		solution, err := chain.Solve(ctx, c)
+		events <- solveDoneMsg{solution, err}
+	}()
+
+	m.solving, m.cancelSolve, m.solveEvents = true, cancel, events
+	m.message = "Solving… Press ESC to cancel"
+	return waitForSolve(events)
+}
+
+// waitForSolve delivers the next message from a background solve
+func waitForSolve(events chan tea.Msg) tea.Cmd {
+	return func() tea.Msg { return <-events }
+}
+
+// solveDone shows the result of a solve and steps into solve mode.
+// Reports each solver's failure reason if none of them succeeds.
+func (m *model) solveDone(solution cube.Solution, err error) {
+	m.solution, m.stages, m.currentMove = nil, nil, 0
+	m.mode = "view"
+	m.playing = false
+	switch {
+	case errors.Is(err, context.Canceled):
+		m.message = "Solve cancelled"
+	case err != nil:
+		m.message = "No solution: " + strings.ReplaceAll(err.Error(), "\n", "; ")
+	default:
+		m.mode = "solve"
+		m.solution, m.stages = solution.Moves, solution.Stages
+		m.message = fmt.Sprintf("%s solution: %d moves (HTM). Press SPACE for next move", solution.Solver, cube.HTM.Count(solution.Moves))
+	}
+}
+
+// validate checks the cube, recording any problems as diagnostics for the
+// view, and reports whether it is valid
+func (m *model) validate() bool {
+	m.diagnostics = nil
+	err := m.cube.Validate()
+	var verr *cube.ValidationError
+	if errors.As(err, &verr) {
+		for _, p := range verr.Problems {
+			m.diagnostics = append(m.diagnostics, p.Detail)
+		}
+	}
+	return err == nil
+}
+
+// stageOf returns the stage containing move i of a solution
+func stageOf(stages []cube.Stage, i int) (cube.Stage, bool) {
+	for _, stage := range stages {
+		if i < len(stage.Moves) {
+			return stage, true
+		}
+		i -= len(stage.Moves)
+	}
+	return cube.Stage{}, false
+}
+
+// nextSolver moves the next registered solver to the front of the chain,
+// keeping the rest as fallbacks
+func nextSolver(chain []string) []string {
+	names := cube.Solvers()
+	next := names[0]
+	if len(chain) > 0 {
+		for i, name := range names {
+			if name == chain[0] {
+				next = names[(i+1)%len(names)]
+			}
+		}
+	}
+
+	result := []string{next}
+	for _, name := range chain {
+		if name != next {
+			result = append(result, name)
+		}
+	}
+	return result
+}
+
+func (m model) View() string {
+	l := m.layout()
+	_, height := m.size()
+	msg := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("86")).
+		Bold(true).
+		Render(m.message)
+	fit := lipgloss.NewStyle().MaxWidth(l.width).MaxHeight(height)
+	if l.minimal {
+		return fit.Render(m.renderCube(l.cube) + "\n" + msg)
+	}
+
+	var s strings.Builder
+
+	// Title
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("205")).
+		Render("🧊 RUBIK'S CUBE SOLVER 🧊")
+	s.WriteString(title + "\n\n")
+
+	// Cube (3D from the camera, or every face flat) and the panels
+	cube := m.renderCube(l.cube)
+	if l.side {
+		cube = lipgloss.NewStyle().Width(l.cube.width + panelGap).Render(cube)
+		s.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, cube, m.renderPanels(l)))
+	} else {
+		s.WriteString(cube + "\n" + m.renderPanels(l))
+	}
+	s.WriteString("\n\n")
+
+	// Controls
+	controls := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(strings.Join(l.controls, "\n"))
+	s.WriteString(controls + "\n\n")
+
+	// Status message
+	s.WriteString(msg + "\n")
+
+	// Validation problems
+	if len(m.diagnostics) > 0 {
+		problems := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+		for i, d := range m.diagnostics {
+			if i == maxDiagnostics && len(m.diagnostics) > maxDiagnostics+1 {
+				s.WriteString(problems.Render(fmt.Sprintf("  ✗ … and %d more", len(m.diagnostics)-i)) + "\n")
+				break
+			}
+			s.WriteString(problems.Render("  ✗ "+d) + "\n")
+		}
+	}
+
+	return fit.Render(strings.TrimSuffix(s.String(), "\n"))
+}
+
+// renderFlatCube renders every face, unfolded around the front. Compact
+// stickers are a cell wide, with no blank lines between the faces.
+func (m model) renderFlatCube(compact bool) string {
+	var lines []string
+	indent := strings.Repeat(" ", netWidth/3+1)
+	gap := []string{""}
+	if compact {
+		indent, gap = strings.Repeat(" ", compactNetWidth/3+1), nil
+	}
+
+	// Render top face (Up)
+	for row := 0; row < 3; row++ {
+		lines = append(lines, indent+m.renderFaceRow(cube.Up, row, compact))
+	}
+	lines = append(lines, gap...)
+
+	// Render middle three faces (Left, Front, Right)
+	for row := 0; row < 3; row++ {
+		lines = append(lines, m.renderFaceRow(cube.Left, row, compact)+" "+
+			m.renderFaceRow(cube.Front, row, compact)+" "+
+			m.renderFaceRow(cube.Right, row, compact))
+	}
+	lines = append(lines, gap...)
+
+	// Render bottom face (Down)
+	for row := 0; row < 3; row++ {
+		lines = append(lines, indent+m.renderFaceRow(cube.Down, row, compact))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// renderFaceRow renders a single row of a face, each sticker three cells
+// wide or, compact, one
+func (m model) renderFaceRow(face cube.Face, row int, compact bool) string {
+	start := row * 3
+	stickers := m.cube.Face(face)
+	colors := stickers[start : start+3]
+	bad := m.badStickers()
+
+	var result string
+	for i, color := range colors {
+		pos := start + i
+		style := m.getColorStyle(color)
+
+		// Highlight current position in input mode
+		if m.mode == "input" && m.inputStep == inputStickers && face == m.inputFace && pos == m.inputPos {
+			style = style.Reverse(true).Bold(true)
+		}
+
+		isBad := bad[cube.Sticker{Face: face, Index: pos}]
+		switch {
+		case compact && isBad:
+			result += style.Render("*")
+		case compact:
+			result += style.Render(color.String())
+		case isBad:
+			result += style.Render("*" + color.String() + "*")
+		default:
+			result += style.Render(" " + color.String() + " ")
+		}
+	}
+
+	return result
+}
+
+// badStickers returns the stickers of the edges that are bad on the axis
+// shown, or nothing when edge orientation is hidden
+func (m model) badStickers() map[cube.Sticker]bool {
+	bad := map[cube.Sticker]bool{}
+	if !m.showEO {
+		return bad
+	}
+	for _, e := range m.cube.BadEdges(m.eoAxis) {
+		for _, s := range e.Stickers() {
+			bad[s] = true
+		}
+	}
+	return bad
+}
+
+// getColorStyle returns the lip gloss style for a color
+func (m model) getColorStyle(c cube.Color) lipgloss.Style {
+	switch c {
+	case cube.White:
+		return lipgloss.NewStyle().Background(lipgloss.Color("255")).Foreground(lipgloss.Color("0"))
+	case cube.Red:
+		return lipgloss.NewStyle().Background(lipgloss.Color("196")).Foreground(lipgloss.Color("255"))
+	case cube.Blue:
+		return lipgloss.NewStyle().Background(lipgloss.Color("21")).Foreground(lipgloss.Color("255"))
+	case cube.Orange:
+		return lipgloss.NewStyle().Background(lipgloss.Color("208")).Foreground(lipgloss.Color("0"))
+	case cube.Green:
+		return lipgloss.NewStyle().Background(lipgloss.Color("28")).Foreground(lipgloss.Color("255"))
+	case cube.Yellow:
+		return lipgloss.NewStyle().Background(lipgloss.Color("226")).Foreground(lipgloss.Color("0"))
+	default:
+		return lipgloss.NewStyle()
+	}
+}
+
+func main() {
+	// Subcommands run without the interactive UI
+	if len(os.Args) > 1 {
+		if command, ok := commands[os.Args[1]]; ok {
+			if err := command(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
+	solvers := flag.String("solver", strings.Join(cube.DefaultChain, ","), solverFlagUsage())
+	seed := flag.Uint64("seed", 0, "scramble seed (0 picks one at random)")
+	moveSet := flag.String("moves", "", "solve using only these moves, e.g. \"<R,U>\" (search-based solvers)")
+	costs := flag.String("costs", "", "cost model file: break ties between equally short solutions by how quick they are to perform (default "+cube.CostModelPath()+" if it exists)")
+	turnTime := flag.Duration("turn-time", defaultTurnTime, "how long a quarter turn takes to draw (0 turns at once)")
+	schemeName := flag.String("scheme", "western", "color scheme of your cube: western, japanese or six colors for F R B L U D, e.g. GRBOWY")
+	flag.Parse()
+
+	scheme, err := cube.ParseColorScheme(*schemeName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	var moves []cube.Move
+	if *moveSet != "" {
+		if moves, err = cube.ParseMoveSet(*moveSet); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	cost, err := costModel(*costs, false)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	p := tea.NewProgram(initialModel(splitList(*solvers), moves, cost, scrambleSeed(*seed), scheme, *turnTime), tea.WithAltScreen())
+	if _, err := p.Run(); err != nil {
+		fmt.Printf("Error: %v", err)
+	}
+}
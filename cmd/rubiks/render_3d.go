@@ -0,0 +1,101 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/michaellavery-grp/rubiks-cube-solver/cube"
+	"github.com/michaellavery-grp/rubiks-cube-solver/render"
+)
+
+// views are the layouts 't' cycles through. The 3D ones start from a camera
+// preset that the arrow keys then turn; the flat one unfolds every face.
+var views = []struct {
+	name   string
+	camera render.Camera
+	flat   bool
+}{
+	{name: "3D Perspective View", camera: render.Perspective},
+	{name: "Isometric View", camera: render.Isometric},
+	{name: "Flat View", flat: true},
+}
+
+// Largest size of the 3D view in terminal cells; smaller terminals get a
+// smaller view of the same shape
+const (
+	viewWidth  = 56
+	viewHeight = 24
+)
+
+// cameraStep is how many degrees an arrow key turns the 3D view
+const cameraStep = 15
+
+// render3DCube draws the cube as the model's camera sees it, width by height
+// cells, with the move being made part way round
+func (m model) render3DCube(width, height int) string {
+	c, twist := m.twist()
+	bad := m.badStickers()
+	if m.turn != nil {
+		bad = nil // they belong to the cube after the move
+	}
+	body := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	// look is how a cell is drawn; neighbouring cells that look the same are
+	// styled together
+	type look struct {
+		kind  render.Kind
+		color cube.Color
+		mark  rune
+	}
+	var lines []string
+	for _, row := range m.camera.Frame(twist, width, height) {
+		var line strings.Builder
+		var run []rune
+		var current look
+		flush := func() {
+			switch current.kind {
+			case render.Blank:
+				line.WriteString(string(run))
+			case render.Body:
+				line.WriteString(body.Render(string(run)))
+			default:
+				line.WriteString(m.getColorStyle(current.color).Render(string(run)))
+			}
+			run = run[:0]
+		}
+		for _, cell := range row {
+			l := look{kind: cell.Kind, mark: ' '}
+			switch cell.Kind {
+			case render.Body:
+				l.mark = 'x'
+			case render.Colored:
+				l.color = c.Sticker(cell.Sticker.Face, cell.Sticker.Index)
+				if bad[cell.Sticker] {
+					l.mark = '*'
+				}
+				// The sticker being entered in input mode
+				if m.mode == "input" && m.inputStep == inputStickers && cell.Sticker == (cube.Sticker{Face: m.inputFace, Index: m.inputPos}) {
+					l.mark = '+'
+				}
+			}
+			if l != current {
+				flush()
+				current = l
+			}
+			run = append(run, l.mark)
+		}
+		flush()
+		lines = append(lines, strings.TrimRight(line.String(), " "))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// faceNames lists faces by their letters, e.g. "U L F"
+func faceNames(faces []cube.Face) string {
+	names := make([]string, len(faces))
+	for i, f := range faces {
+		names[i] = f.String()
+	}
+	return strings.Join(names, " ")
+}
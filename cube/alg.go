@@ -0,0 +1,124 @@
+package cube
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Alg is a parsed algorithm. Besides plain moves it keeps the structure it
+// was written with (groups, repeats, commutators and conjugates), so it can
+// be printed back in the same compact form.
+type Alg interface {
+	// Expand returns the flat move sequence the algorithm performs
+	Expand() []Move
+	// Invert returns the algorithm that undoes this one, keeping its
+	// structure where it can: the inverse of [A, B] is [B, A]
+	Invert() Alg
+	// String renders the algorithm in compact notation
+	String() string
+}
+
+// Moves is a run of plain moves, e.g. R U R' U'
+type Moves []Move
+
+func (m Moves) Expand() []Move { return append([]Move(nil), m...) }
+func (m Moves) Invert() Alg    { return Moves(Invert(m)) }
+func (m Moves) String() string { return FormatMoves(m) }
+
+// Sequence is algorithms performed one after another
+type Sequence []Alg
+
+func (s Sequence) Expand() []Move {
+	var moves []Move
+	for _, a := range s {
+		moves = append(moves, a.Expand()...)
+	}
+	return moves
+}
+
+func (s Sequence) Invert() Alg {
+	inv := make(Sequence, len(s))
+	for i, a := range s {
+		inv[len(s)-1-i] = a.Invert()
+	}
+	return inv
+}
+
+func (s Sequence) String() string {
+	parts := make([]string, len(s))
+	for i, a := range s {
+		parts[i] = a.String()
+	}
+	return strings.Join(parts, " ")
+}
+
+// Repeat is an algorithm performed Count times, and inverted if Inverse is
+// set: (R U R' U')3 or (R U)'
+type Repeat struct {
+	Alg     Alg
+	Count   int
+	Inverse bool
+}
+
+func (r Repeat) Expand() []Move {
+	once := r.Alg.Expand()
+	if r.Inverse {
+		once = Invert(once)
+	}
+	moves := make([]Move, 0, len(once)*r.Count)
+	for i := 0; i < r.Count; i++ {
+		moves = append(moves, once...)
+	}
+	return moves
+}
+
+func (r Repeat) Invert() Alg {
+	r.Inverse = !r.Inverse
+	return r
+}
+
+func (r Repeat) String() string {
+	var s strings.Builder
+	switch r.Alg.(type) {
+	case Commutator, Conjugate:
+		s.WriteString(r.Alg.String())
+	default:
+		s.WriteString("(" + r.Alg.String() + ")")
+	}
+	if r.Count != 1 {
+		s.WriteString(strconv.Itoa(r.Count))
+	}
+	if r.Inverse {
+		s.WriteString("'")
+	}
+	return s.String()
+}
+
+// Commutator [A, B] performs A B A' B'
+type Commutator struct {
+	A, B Alg
+}
+
+func (c Commutator) Expand() []Move {
+	a, b := c.A.Expand(), c.B.Expand()
+	moves := append(a, b...)
+	moves = append(moves, Invert(a)...)
+	return append(moves, Invert(b)...)
+}
+
+func (c Commutator) Invert() Alg    { return Commutator{A: c.B, B: c.A} }
+func (c Commutator) String() string { return "[" + c.A.String() + ", " + c.B.String() + "]" }
+
+// Conjugate [A: B] performs A B A'
+type Conjugate struct {
+	A, B Alg
+}
+
+func (c Conjugate) Expand() []Move {
+	a := c.A.Expand()
+	moves := append(a, c.B.Expand()...)
+	return append(moves, Invert(a)...)
+}
+
+func (c Conjugate) Invert() Alg    { return Conjugate{A: c.A, B: c.B.Invert()} }
+func (c Conjugate) String() string { return "[" + c.A.String() + ": " + c.B.String() + "]" }
@@ -0,0 +1,92 @@
+package cube
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestParseAlg(t *testing.T) {
+	tests := []struct {
+		input    string
+		compact  string // String()
+		expanded string // Expand()
+		inverse  string // Invert().String()
+	}{
+		{"R U R' U'", "R U R' U'", "R U R' U'", "U R U' R'"},
+		{"[R U R', D]", "[R U R', D]", "R U R' D R U' R' D'", "[D, R U R']"},
+		{"[U: [R, D]]", "[U: [R, D]]", "U R D R' D' U'", "[U: [D, R]]"},
+		{"[R,U]2'", "[R, U]2'", "U R U' R' U R U' R'", "[R, U]2"},
+		{"(R U R' U')3", "(R U R' U')3", "R U R' U' R U R' U' R U R' U'", "(R U R' U')3'"},
+		{"F (R U) [R: U] B", "F R U [R: U] B", "F R U R U R' B", "B' [R: U'] U' R' F'"},
+		{"[F: [R, U]] // yellow cross", "[F: [R, U]]", "F R U R' U' F'", "[F: [U, R]]"},
+	}
+	for _, tt := range tests {
+		alg, err := ParseAlg(tt.input)
+		if err != nil {
+			t.Errorf("ParseAlg(%q): %v", tt.input, err)
+			continue
+		}
+		if got := alg.String(); got != tt.compact {
+			t.Errorf("ParseAlg(%q).String() = %q, want %q", tt.input, got, tt.compact)
+		}
+		if got := FormatMoves(alg.Expand()); got != tt.expanded {
+			t.Errorf("ParseAlg(%q).Expand() = %q, want %q", tt.input, got, tt.expanded)
+		}
+		inv := alg.Invert()
+		if got := inv.String(); got != tt.inverse {
+			t.Errorf("ParseAlg(%q).Invert() = %q, want %q", tt.input, got, tt.inverse)
+		}
+		if !slices.Equal(inv.Expand(), Invert(alg.Expand())) {
+			t.Errorf("ParseAlg(%q).Invert() expands to %s, want %s", tt.input,
+				FormatMoves(inv.Expand()), FormatMoves(Invert(alg.Expand())))
+		}
+		// Printing and parsing again gives the same algorithm
+		again, err := ParseAlg(alg.String())
+		if err != nil || again.String() != alg.String() {
+			t.Errorf("ParseAlg(%q) does not round trip: %v", alg.String(), err)
+		}
+	}
+}
+
+func TestParseAlgSyntaxErrors(t *testing.T) {
+	tests := []struct {
+		input        string
+		line, column int
+	}{
+		{"[R U", 1, 1},
+		{"[R U]", 1, 5},
+		{"[R, U", 1, 1},
+		{"R, U", 1, 2},
+		{"[R: U)", 1, 6},
+	}
+	for _, tt := range tests {
+		_, err := ParseAlg(tt.input)
+		var serr *SyntaxError
+		if !errors.As(err, &serr) {
+			t.Errorf("ParseAlg(%q) error = %v, want a *SyntaxError", tt.input, err)
+			continue
+		}
+		if serr.Line != tt.line || serr.Column != tt.column {
+			t.Errorf("ParseAlg(%q) error at %d:%d, want %d:%d (%v)", tt.input, serr.Line, serr.Column, tt.line, tt.column, err)
+		}
+	}
+}
+
+func TestAlgorithmLibrary(t *testing.T) {
+	tests := []struct {
+		alg  Alg
+		want string
+	}{
+		{yellowCrossAlgorithm(), "F R U R' U' F'"},
+		{yellowCornersPositionAlgorithm(), "U R U' L' U R' U' L"},
+		{yellowCornersOrientAlgorithm(), "R' D' R D"},
+		{tPermAlgorithm(), "R U R' U' R' F R2 U' R' U' R U R' F'"},
+		{yPermAlgorithm(), "F R U' R' U' R U R' F' R U R' U' R' F R F'"},
+	}
+	for _, tt := range tests {
+		if got := FormatMoves(tt.alg.Expand()); got != tt.want {
+			t.Errorf("%s expands to %s, want %s", tt.alg, got, tt.want)
+		}
+	}
+}
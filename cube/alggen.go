@@ -0,0 +1,99 @@
+package cube
+
+import (
+	"cmp"
+	"context"
+	"slices"
+)
+
+// Algorithm generation: many algorithms for one case, ranked for learning
+// rather than just the shortest. A case is a cube state, typically reached
+// by a setup algorithm, and a Goal for the part of the cube an algorithm for
+// it must solve; the rest may end anywhere, e.g. the last layer's edges for
+// a corner algorithm. Algorithms come from the restricted search (see
+// subset.go), so they can be limited to moves like <R,U,F> that are quick to
+// perform, and are ranked with a CostModel (see ergonomics.go).
+
+// GenerateOptions configure GenerateAlgs
+type GenerateOptions struct {
+	// Moves are the moves algorithms may use; nil means the 18 face turns
+	Moves []Move
+	// Goal is what an algorithm must solve, e.g. from ParseGoal; the zero
+	// Goal means the whole cube. Its AUF accepts algorithms that leave a
+	// turn of U to do at the end.
+	Goal Goal
+	// MaxDepth bounds the length of algorithms; 0 means 14
+	MaxDepth int
+	// Count is how many algorithms to return; 0 means 10
+	Count int
+	// Cost ranks algorithms of the same length; nil means
+	// DefaultCostModel
+	Cost *CostModel
+	// ByCost ranks by cost alone, so a longer algorithm can come first if
+	// it is quicker to perform. Only lengths up to the one at which Count
+	// algorithms turn up are searched.
+	ByCost bool
+}
+
+// GenerateAlgs returns algorithms for the case c is in: the shortest
+// first, and among those as long the quickest to perform, or by cost alone
+// with ByCost. It returns ErrUnreachable if the moves can't solve the
+// case, as SolveGoal finds out. If ctx ends first, it returns the
+// algorithms found by then.
+func GenerateAlgs(ctx context.Context, c *Cube, opts GenerateOptions) ([][]Move, error) {
+	q := subsetQuery{moves: opts.Moves, maxDepth: opts.MaxDepth, n: opts.Count, wholeDepths: true}
+	if q.moves == nil {
+		q.moves = faceTurns
+	}
+	if q.maxDepth <= 0 {
+		q.maxDepth = 14
+	}
+	if q.n <= 0 {
+		q.n = 10
+	}
+	q.goal = opts.Goal.subset()
+	if q.goal == (subsetGoal{auf: q.goal.auf}) {
+		q.goal.corners, q.goal.edges = solveAll.corners, solveAll.edges
+	}
+
+	algs, err := solveSubset(ctx, c, q)
+	if err != nil {
+		return nil, err
+	}
+	t, _ := loadSubsetTables(q.moves, q.goal)
+	cc, _ := cubieFromFacelets(c.KociembaString())
+	algs = slices.DeleteFunc(algs, func(alg []Move) bool { return padded(t, cc, alg) })
+	cost := opts.Cost
+	if cost == nil {
+		cost = DefaultCostModel()
+	}
+	slices.SortStableFunc(algs, func(a, b []Move) int {
+		if opts.ByCost {
+			return cmp.Or(cmp.Compare(cost.Cost(a), cost.Cost(b)), cmp.Compare(len(a), len(b)))
+		}
+		return cost.compare(a, b)
+	})
+	return algs[:min(len(algs), q.n)], nil
+}
+
+// padded reports whether alg turns a layer for nothing: whether it still
+// solves the case without its last move and an earlier turn of the same
+// layer, as D A D' does if A solves a last layer case
+func padded(t *subsetTables, cc CubieCube, alg []Move) bool {
+	if len(alg) < 3 {
+		return false
+	}
+	last, _ := splitMove(alg[len(alg)-1])
+	for i, m := range alg[:len(alg)-2] {
+		if base, _ := splitMove(m); base != last {
+			continue
+		}
+		v := cc
+		v.ApplyMoves(alg[:i])
+		v.ApplyMoves(alg[i+1 : len(alg)-1])
+		if t.solved(&v) {
+			return true
+		}
+	}
+	return false
+}
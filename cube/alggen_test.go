@@ -0,0 +1,118 @@
+package cube
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGenerateAlgs(t *testing.T) {
+	// Undoing a Sune with F2L and the corners to solve: its inverse is the
+	// shortest algorithm, and D turns are never needed
+	c := NewCube()
+	c.ApplyMoves(MustParseMoves("R U R' U R U2 R'"))
+	set, _ := ParseMoveSet("<R,U,D>")
+	goal, err := ParseGoal("F2L,corners,AUF")
+	if err != nil {
+		t.Fatal(err)
+	}
+	algs, err := GenerateAlgs(context.Background(), c, GenerateOptions{
+		Moves: set, Goal: goal, MaxDepth: 10, Count: 5,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(algs) == 0 || FormatMoves(algs[0]) != "R U2 R' U' R U' R'" {
+		t.Fatalf("got %v, want R U2 R' U' R U' R' first", algs)
+	}
+	for i, alg := range algs {
+		if i > 0 && DefaultCostModel().compare(algs[i-1], alg) > 0 {
+			t.Errorf("%s comes after a longer or slower algorithm", FormatMoves(alg))
+		}
+		// Padding like D A D' adds nothing to an algorithm A
+		for _, m := range alg {
+			if base, _ := splitMove(m); base == "D" {
+				t.Errorf("%s turns D", FormatMoves(alg))
+			}
+		}
+		check := c.Clone()
+		check.ApplyMoves(alg)
+		if !goal.Reached(check) {
+			t.Errorf("%s does not solve the case", FormatMoves(alg))
+		}
+	}
+}
+
+func TestGenerateAlgsMask(t *testing.T) {
+	// With the edges swapped as well, the inverse Sune solves only the
+	// corners; asking for just those must find it
+	c := NewCube()
+	c.ApplyMoves(MustParseMoves("R U R' U R U2 R' M2 U M2 U2 M2 U M2"))
+	set, _ := ParseMoveSet("<R,U>")
+	goal, _ := ParseGoal("F2L,corners")
+	algs, err := GenerateAlgs(context.Background(), c, GenerateOptions{
+		Moves: set, Goal: goal, MaxDepth: 7, Count: 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(algs) != 1 || len(algs[0]) != 7 {
+		t.Fatalf("got %v, want one 7 move algorithm", algs)
+	}
+	c.ApplyMoves(algs[0])
+	if !goal.Reached(c) || c.IsSolved() {
+		t.Fatalf("%s left %s", FormatMoves(algs[0]), c.KociembaString())
+	}
+}
+
+func TestGenerateAlgsByCost(t *testing.T) {
+	// With B made free, an algorithm turning B is quicker than F R U R'
+	// U' F' though as long
+	c := NewCube()
+	c.ApplyMoves(MustParseMoves("F R U R' U' F'"))
+	goal, _ := ParseGoal("F2L,UF,UR,UB,UL")
+	cost := DefaultCostModel()
+	cost.Layers["B"] = 0
+	set, _ := ParseMoveSet("<R,U,F,B>")
+	opts := GenerateOptions{Moves: set, Goal: goal, MaxDepth: 8, Count: 4, Cost: cost}
+	byLength, err := GenerateAlgs(context.Background(), c, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts.ByCost = true
+	byCost, err := GenerateAlgs(context.Background(), c, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(byLength[0]) != 6 || len(byCost[0]) != 8 {
+		t.Fatalf("by length %v, by cost %v", byLength, byCost)
+	}
+	for i := 1; i < len(byCost); i++ {
+		if cost.Cost(byCost[i]) < cost.Cost(byCost[i-1]) {
+			t.Errorf("%s comes after a slower algorithm", FormatMoves(byCost[i]))
+		}
+	}
+}
+
+func TestParseFacelets(t *testing.T) {
+	c := NewCube()
+	c.ApplyMoves(MustParseMoves("R U F' D2 L B"))
+	parsed, err := ParseFacelets(c.KociembaString())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.KociembaString() != c.KociembaString() {
+		t.Fatalf("got %s, want %s", parsed.KociembaString(), c.KociembaString())
+	}
+	solved := NewCube().KociembaString()
+	for _, bad := range []string{
+		"",
+		solved[:53],
+		solved[:4] + "X" + solved[5:],
+		solved[:4] + "R" + solved[5:], // centers out of order
+		solved[:8] + "R" + solved[9:10] + "U" + solved[11:], // two stickers swapped
+	} {
+		if _, err := ParseFacelets(bad); err == nil {
+			t.Errorf("%q: expected an error", bad)
+		}
+	}
+}
@@ -0,0 +1,692 @@
+package cube
+
+import (
+	"context"
+	"fmt"
+)
+
+// Beginner's Method Solver
+// Based on layer-by-layer solving: https://ruwix.com/the-rubiks-cube/how-to-solve-the-rubiks-cube-beginners-method/
+
+// Step 4: Yellow Cross Algorithm
+// Algorithm: F R U R' U' F'
+func yellowCrossAlgorithm() Alg {
+	return MustParseAlg("[F: [R, U]]")
+}
+
+// Step 5: Yellow Edges Algorithm
+// Algorithm: R U R' U R U2 R' U
+func yellowEdgesAlgorithm() Alg {
+	return MustParseAlg("R U R' U R U2 R' U")
+}
+
+// Step 6: Yellow Corners Position Algorithm
+// Algorithm: U R U' L' U R' U' L
+func yellowCornersPositionAlgorithm() Alg {
+	return MustParseAlg("[U R U', L']")
+}
+
+// Step 7: Yellow Corners Orient Algorithm
+// Algorithm: R' D' R D
+func yellowCornersOrientAlgorithm() Alg {
+	return MustParseAlg("[R', D']")
+}
+
+// Step 3: Second Layer - Left Edge Algorithm
+// Algorithm: U' L' U L U F U' F'
+func secondLayerLeftAlgorithm() Alg {
+	return MustParseAlg("U' L' U L U F U' F'")
+}
+
+// Step 3: Second Layer - Right Edge Algorithm
+// Algorithm: U R U' R' U' F' U F
+func secondLayerRightAlgorithm() Alg {
+	return MustParseAlg("U R U' R' U' F' U F")
+}
+
+// Sune Algorithm (for orienting last layer corners)
+// Algorithm: R U R' U R U2 R'
+func suneAlgorithm() Alg {
+	return MustParseAlg("R U R' U R U2 R'")
+}
+
+// Anti-Sune Algorithm
+// Algorithm: R U2 R' U' R U' R'
+func antiSuneAlgorithm() Alg {
+	return MustParseAlg("R U2 R' U' R U' R'")
+}
+
+// T-Perm Algorithm (permute last layer corners)
+// Algorithm: R U R' U' R' F R2 U' R' U' R U R' F'
+func tPermAlgorithm() Alg {
+	return MustParseAlg("[R, U] R' F R2 U' R' U' R U R' F'")
+}
+
+// Ja-Perm Algorithm (permute last layer edges)
+// Algorithm: R' U L' U2 R U' R' U2 R L
+func jaPermAlgorithm() Alg {
+	return MustParseAlg("R' U L' U2 R U' R' U2 R L")
+}
+
+// Y-Perm Algorithm (swap diagonal corners)
+// Algorithm: F R U' R' U' R U R' F' R U R' U' R' F R F'
+func yPermAlgorithm() Alg {
+	return MustParseAlg("F R U' R' U' R U R' F' [R, U] R' F R F'")
+}
+
+// Helper: Apply algorithm to cube
+func applyAlgorithm(c *Cube, alg Alg) {
+	c.ApplyMoves(alg.Expand())
+}
+
+// Check if white cross is complete
+func (c *Cube) IsWhiteCrossComplete() bool {
+	up := Up
+	// Check center is white
+	if c.faces[up][4] != White {
+		return false
+	}
+	// Check edges are white
+	edges := []int{1, 3, 5, 7}
+	for _, idx := range edges {
+		if c.faces[up][idx] != White {
+			return false
+		}
+	}
+	return true
+}
+
+// Check if white face is complete (first layer done)
+func (c *Cube) IsWhiteFaceComplete() bool {
+	up := Up
+	for i := 0; i < 9; i++ {
+		if c.faces[up][i] != White {
+			return false
+		}
+	}
+	return true
+}
+
+// Check if yellow cross is formed: the Down edges match the Down center,
+// which is yellow in the Western scheme
+func (c *Cube) IsYellowCrossFormed() bool {
+	down := Down
+	// Check edges match the center
+	edges := []int{1, 3, 5, 7}
+	for _, idx := range edges {
+		if c.faces[down][idx] != c.faces[down][4] {
+			return false
+		}
+	}
+	return true
+}
+
+// Check if yellow face is complete, i.e. the Down face is one color
+func (c *Cube) IsYellowFaceComplete() bool {
+	down := Down
+	for i := 0; i < 9; i++ {
+		if c.faces[down][i] != c.faces[down][4] {
+			return false
+		}
+	}
+	return true
+}
+
+// Check if the first two layers are solved: the white face and every
+// white-layer and middle-layer piece matches its centers
+func (c *Cube) IsSecondLayerComplete() bool {
+	if !c.IsWhiteFaceComplete() {
+		return false
+	}
+	return c.piecesSolved([]Corner{URF, UFL, ULB, UBR}, []Edge{UR, UF, UL, UB, FR, FL, BL, BR})
+}
+
+// piecesSolved reports whether the given corner and edge positions hold
+// their own pieces, correctly oriented
+func (c *Cube) piecesSolved(corners []Corner, edges []Edge) bool {
+	for _, i := range corners {
+		for _, k := range cornerFacelet[i] {
+			if !c.faceletSolved(k) {
+				return false
+			}
+		}
+	}
+	for _, i := range edges {
+		for _, k := range edgeFacelet[i] {
+			if !c.faceletSolved(k) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// faceletSolved reports whether sticker k of the Kociemba string matches
+// the center of its face
+func (c *Cube) faceletSolved(k int) bool {
+	return c.facelet(k) == c.faces[kociembaFaceOrder[k/9]][4]
+}
+
+// findEdge returns the position of the edge colored a and b, and whether
+// it is flipped, i.e. a is not on the position's reference facelet
+func (c *Cube) findEdge(a, b Color) (pos Edge, flipped bool) {
+	for i, f := range edgeFacelet {
+		x, y := c.facelet(f[0]), c.facelet(f[1])
+		if x == a && y == b {
+			return Edge(i), false
+		}
+		if x == b && y == a {
+			return Edge(i), true
+		}
+	}
+	return -1, false
+}
+
+// findCorner returns the position of the corner colored a, b and d, and
+// which of the position's facelets shows a
+func (c *Cube) findCorner(a, b, d Color) (pos Corner, twist int) {
+	for i, f := range cornerFacelet {
+		var colors [3]Color
+		for n, k := range f {
+			colors[n] = c.facelet(k)
+		}
+		for n := 0; n < 3; n++ {
+			if colors[n] == a && colors[(n+1)%3] == b && colors[(n+2)%3] == d {
+				return Corner(i), n
+			}
+			if colors[n] == a && colors[(n+1)%3] == d && colors[(n+2)%3] == b {
+				return Corner(i), n
+			}
+		}
+	}
+	return -1, 0
+}
+
+// cornerPlaced reports whether the corner at position i is the one that
+// belongs there, however it is twisted
+func (c *Cube) cornerPlaced(i Corner) bool {
+	var want, have [6]int
+	for _, k := range cornerFacelet[i] {
+		want[c.faces[kociembaFaceOrder[k/9]][4]]++
+		have[c.facelet(k)]++
+	}
+	return want == have
+}
+
+// Beginner's Method Solver
+// Solves layer by layer: the first layer with white on top, then the cube
+// is turned over and the second and last layers are solved with yellow on
+// top, the way the method is usually taught. Each step locates the pieces
+// it needs, picks the case and setup moves, and checks its result before
+// moving on. The moves are written as a learner makes them: whole-cube
+// rotations (x2 to turn the cube over, y to bring another slot to the
+// front) change how the cube is held, and the algorithms follow as taught.
+func (c *Cube) SolveBeginnerMethod() (solution []Move, stages []Stage, err error) {
+	if err := c.Validate(); err != nil {
+		return nil, nil, err
+	}
+	// The method is taught with white on top, so hold the cube that way.
+	// Whatever is opposite white (yellow in the Western scheme) is the last
+	// layer.
+	hold, _ := c.holding(func(h *Cube) bool { return h.faces[Up][4] == White })
+
+	s := &beginnerSolve{cube: c.held(hold), hold: hold.inverse()}
+	for _, step := range beginnerSteps {
+		s.moves = nil
+		skipped := step.done(s.cube)
+		if err := step.solve(s); err != nil {
+			return nil, nil, fmt.Errorf("%s: %v", step.name, err)
+		}
+		if !step.done(s.cube) {
+			return nil, nil, fmt.Errorf("%s: step did not complete", step.name)
+		}
+		// Setup moves and algorithms often meet with moves that cancel,
+		// such as D D'; tidy them up within the step
+		moves := Simplify(s.moves)
+		stages = append(stages, Stage{
+			Name:        step.name,
+			Description: step.description,
+			Moves:       moves,
+			Skipped:     skipped,
+		})
+		solution = append(solution, moves...)
+	}
+	return solution, stages, nil
+}
+
+// beginnerSteps are the seven steps of the method, each with the predicate
+// that must hold once it is done
+var beginnerSteps = []struct {
+	name        string
+	description string
+	solve       func(s *beginnerSolve) error
+	done        func(c *Cube) bool
+}{
+	{
+		"White cross",
+		"Place the four white edges around the white center, each matching the center of its side",
+		(*beginnerSolve).whiteCross,
+		func(c *Cube) bool {
+			return c.IsWhiteCrossComplete() && c.piecesSolved(nil, []Edge{UR, UF, UL, UB})
+		},
+	},
+	{
+		"White corners",
+		"Hold each white corner's slot at the front right, bring the corner below it and repeat R' D' R D until it drops in",
+		(*beginnerSolve).whiteCorners,
+		func(c *Cube) bool {
+			return c.IsWhiteFaceComplete() && c.piecesSolved([]Corner{URF, UFL, ULB, UBR}, []Edge{UR, UF, UL, UB})
+		},
+	},
+	{
+		"Second layer",
+		"Turn the cube over (x2) and insert the middle edges with U R U' R' U' F' U F or U' L' U L U F U' F'",
+		(*beginnerSolve).secondLayer,
+		(*Cube).IsSecondLayerComplete,
+	},
+	{
+		"Yellow cross",
+		"Repeat F R U R' U' F' from a dot, an L or a line until the yellow cross appears",
+		(*beginnerSolve).yellowCross,
+		func(c *Cube) bool { return c.IsSecondLayerComplete() && c.IsYellowCrossFormed() },
+	},
+	{
+		"Yellow edges",
+		"Swap the yellow edges with R U R' U R U2 R' U until each matches its side center",
+		(*beginnerSolve).yellowEdges,
+		func(c *Cube) bool {
+			return c.IsSecondLayerComplete() && c.piecesSolved(nil, []Edge{DR, DF, DL, DB})
+		},
+	},
+	{
+		"Yellow corners position",
+		"Cycle the yellow corners with U R U' L' U R' U' L until each sits between its three centers",
+		(*beginnerSolve).yellowCornersPosition,
+		func(c *Cube) bool {
+			for _, i := range []Corner{DFR, DLF, DBL, DRB} {
+				if !c.cornerPlaced(i) {
+					return false
+				}
+			}
+			return c.IsSecondLayerComplete() && c.piecesSolved(nil, []Edge{DR, DF, DL, DB})
+		},
+	},
+	{
+		"Yellow corners orient",
+		"Twist each yellow corner with R' D' R D, turning only U between corners",
+		(*beginnerSolve).yellowCornersOrient,
+		(*Cube).IsSolved,
+	},
+}
+
+// beginnerSolve tracks the cube while the method is worked through. Moves
+// are planned while holding the cube turned some way (see whiteUp and
+// yellowUp) and recorded as made that way, after the rotations that turn
+// the cube from how it was held before.
+type beginnerSolve struct {
+	cube  *Cube // held white up
+	moves []Move
+	hold  rotation // how the moves recorded last were held, from cube
+}
+
+// errBeginnerStuck means a step kept going without reaching its goal,
+// which can only happen on a cube that can't be solved
+var errBeginnerStuck = fmt.Errorf("no progress after repeated attempts")
+
+// maxStepAttempts bounds the case-and-algorithm loop of every step
+const maxStepAttempts = 12
+
+// whiteUp holds the cube as stored, white on top, turned k quarter turns
+// about the vertical axis so a different side faces front
+func whiteUp(k int) rotation {
+	return rotationY.times(k % 4)
+}
+
+// yellowUp holds the cube turned over, yellow on top, then turned k
+// quarter turns about the vertical axis
+func yellowUp(k int) rotation {
+	return rotationX.times(2).then(rotationY.times(k % 4))
+}
+
+// view returns the cube as seen when held turned by r
+func (s *beginnerSolve) view(r rotation) *Cube {
+	return s.cube.held(r)
+}
+
+// do applies alg while holding the cube turned by r
+func (s *beginnerSolve) do(r rotation, alg ...Move) {
+	if len(alg) == 0 {
+		return
+	}
+	if r != s.hold {
+		s.moves = append(s.moves, holdMoves(s.hold.inverse().then(r))...)
+		s.hold = r
+	}
+	for _, m := range alg {
+		s.cube.ApplyMove(r.unheld(m))
+		s.moves = append(s.moves, m)
+	}
+}
+
+// turnUntil turns face m (held by r) the fewest quarter turns that satisfy
+// ok, and reports whether any did
+func (s *beginnerSolve) turnUntil(r rotation, m Move, ok func(v *Cube) bool) bool {
+	v := s.view(r)
+	for n := 0; n < 4; n++ {
+		if ok(v) {
+			s.do(r, quarterTurns(m, n)...)
+			return true
+		}
+		v.ApplyMove(m)
+	}
+	return false
+}
+
+// quarterTurns returns n clockwise quarter turns of m, written the short way
+func quarterTurns(m Move, n int) []Move {
+	switch n % 4 {
+	case 1:
+		return []Move{m}
+	case 2:
+		return []Move{m + "2"}
+	case 3:
+		return []Move{m.Inverse()}
+	}
+	return nil
+}
+
+// Step 1: each white edge in turn is brought to the D layer and then up
+// into its slot at UF, with the cube held so that slot faces front
+func (s *beginnerSolve) whiteCross() error {
+	for k := 0; k < 4; k++ {
+		r := whiteUp(k)
+		side := s.view(r).faces[Front][4]
+		for attempt := 0; ; attempt++ {
+			if attempt == maxStepAttempts {
+				return errBeginnerStuck
+			}
+			pos, flipped := s.view(r).findEdge(White, side)
+			if pos == UF && !flipped {
+				break
+			}
+			switch pos {
+			// In the top layer but not solved: take it down
+			case UR:
+				s.do(r, R, R)
+			case UF:
+				s.do(r, F, F)
+			case UL:
+				s.do(r, L, L)
+			case UB:
+				s.do(r, B, B)
+			// In the middle layer: drop it to D without disturbing the top
+			case FR:
+				s.do(r, Ri, D, R)
+			case FL:
+				s.do(r, L, D, Li)
+			case BL:
+				s.do(r, Li, D, L)
+			case BR:
+				s.do(r, R, D, Ri)
+			default:
+				// In the D layer: turn D to bring it under its slot, then
+				// bring it up white side on top
+				s.turnUntil(r, D, func(v *Cube) bool {
+					p, _ := v.findEdge(White, side)
+					return p == DF
+				})
+				if _, flipped := s.view(r).findEdge(White, side); flipped {
+					s.do(r, Di, Li, F, L)
+				} else {
+					s.do(r, F, F)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Step 2: each white corner is brought below its slot at URF and R' D' R D
+// is repeated until it sits in place with white on top
+func (s *beginnerSolve) whiteCorners() error {
+	for k := 0; k < 4; k++ {
+		r := whiteUp(k)
+		v := s.view(r)
+		front, right := v.faces[Front][4], v.faces[Right][4]
+		for attempt := 0; ; attempt++ {
+			if attempt == maxStepAttempts {
+				return errBeginnerStuck
+			}
+			pos, twist := s.view(r).findCorner(White, right, front)
+			if pos == URF && twist == 0 {
+				break
+			}
+			switch pos {
+			case URF:
+				// In its slot but twisted: the algorithm cycles it through
+				// DFR until it comes back the right way round
+				s.do(r, yellowCornersOrientAlgorithm().Expand()...)
+			// In another top slot: hold that slot at the front right and
+			// push the corner down
+			case UBR:
+				s.do(whiteUp(k+1), yellowCornersOrientAlgorithm().Expand()...)
+			case ULB:
+				s.do(whiteUp(k+2), yellowCornersOrientAlgorithm().Expand()...)
+			case UFL:
+				s.do(whiteUp(k+3), yellowCornersOrientAlgorithm().Expand()...)
+			default:
+				// In the D layer: bring it under its slot and insert it
+				s.turnUntil(r, D, func(v *Cube) bool {
+					p, _ := v.findCorner(White, right, front)
+					return p == DFR
+				})
+				s.do(r, yellowCornersOrientAlgorithm().Expand()...)
+			}
+		}
+	}
+	return nil
+}
+
+// Step 3: with yellow on top, each middle edge is lined up over the center
+// matching its side color and inserted to the right or left
+func (s *beginnerSolve) secondLayer() error {
+	for k := 0; k < 4; k++ {
+		r := yellowUp(k)
+		v := s.view(r)
+		front, right := v.faces[Front][4], v.faces[Right][4]
+		for attempt := 0; ; attempt++ {
+			if attempt == maxStepAttempts {
+				return errBeginnerStuck
+			}
+			pos, flipped := s.view(r).findEdge(front, right)
+			if pos == FR && !flipped {
+				break
+			}
+			switch pos {
+			case UR, UF, UL, UB:
+				if flipped {
+					// Front color on the side: line it up over the front
+					// center and insert it to the right
+					s.turnUntil(r, U, func(v *Cube) bool {
+						p, _ := v.findEdge(front, right)
+						return p == UF
+					})
+					s.do(r, secondLayerRightAlgorithm().Expand()...)
+				} else {
+					// Right color on the side: line it up over the right
+					// center, then face that side and insert to the left
+					s.turnUntil(r, U, func(v *Cube) bool {
+						p, _ := v.findEdge(front, right)
+						return p == UR
+					})
+					s.do(yellowUp(k+1), secondLayerLeftAlgorithm().Expand()...)
+				}
+			// In the wrong middle slot or flipped: pop it out to the top
+			// by inserting any top edge there
+			case FR:
+				s.do(r, secondLayerRightAlgorithm().Expand()...)
+			case BR:
+				s.do(yellowUp(k+1), secondLayerRightAlgorithm().Expand()...)
+			case BL:
+				s.do(yellowUp(k+2), secondLayerRightAlgorithm().Expand()...)
+			case FL:
+				s.do(yellowUp(k+3), secondLayerRightAlgorithm().Expand()...)
+			default:
+				return fmt.Errorf("middle edge found in the first layer")
+			}
+		}
+	}
+	return nil
+}
+
+// Step 4: F R U R' U' F' turns a dot into an L, an L held at the back left
+// into a line, and a horizontal line into the cross
+func (s *beginnerSolve) yellowCross() error {
+	yellowAt := func(v *Cube, i int) bool { return v.faces[Up][i] == v.faces[Up][4] }
+	for attempt := 0; ; attempt++ {
+		if attempt == maxStepAttempts {
+			return errBeginnerStuck
+		}
+		v := s.view(yellowUp(0))
+		count := 0
+		for _, i := range []int{1, 3, 5, 7} {
+			if yellowAt(v, i) {
+				count++
+			}
+		}
+		switch {
+		case count == 4:
+			return nil
+		case count == 0:
+			s.do(yellowUp(0), yellowCrossAlgorithm().Expand()...)
+		default:
+			// Hold the L with its arms at the back and left, or the line
+			// running left to right
+			for k := 0; k < 4; k++ {
+				v := s.view(yellowUp(k))
+				if (yellowAt(v, 1) && yellowAt(v, 3)) || (yellowAt(v, 3) && yellowAt(v, 5)) {
+					s.do(yellowUp(k), yellowCrossAlgorithm().Expand()...)
+					break
+				}
+			}
+		}
+	}
+}
+
+// Step 5: turn U so as many yellow edges as possible match their side
+// centers; two matching neighbours are held at the back and right and the
+// other two swapped
+func (s *beginnerSolve) yellowEdges() error {
+	topEdges := []Edge{UR, UF, UL, UB}
+	matching := func(v *Cube) int {
+		n := 0
+		for _, e := range topEdges {
+			if v.piecesSolved(nil, []Edge{e}) {
+				n++
+			}
+		}
+		return n
+	}
+	for attempt := 0; ; attempt++ {
+		if attempt == maxStepAttempts {
+			return errBeginnerStuck
+		}
+		best, v := 0, s.view(yellowUp(0))
+		for n := 0; n < 4; n++ {
+			best = max(best, matching(v))
+			v.ApplyMove(U)
+		}
+		s.turnUntil(yellowUp(0), U, func(v *Cube) bool { return matching(v) == best })
+		if best == 4 {
+			return nil
+		}
+
+		held := yellowUp(0)
+		for k := 0; k < 4; k++ {
+			v := s.view(yellowUp(k))
+			if v.piecesSolved(nil, []Edge{UB, UR}) {
+				held = yellowUp(k)
+				break
+			}
+		}
+		s.do(held, yellowEdgesAlgorithm().Expand()...)
+	}
+}
+
+// Step 6: U R U' L' U R' U' L cycles the three corners other than URF, so a
+// corner already in its place is held at the front right
+func (s *beginnerSolve) yellowCornersPosition() error {
+	for attempt := 0; ; attempt++ {
+		if attempt == maxStepAttempts {
+			return errBeginnerStuck
+		}
+		placed, held := 0, yellowUp(0)
+		for k := 3; k >= 0; k-- {
+			if s.view(yellowUp(k)).cornerPlaced(URF) {
+				placed++
+				held = yellowUp(k)
+			}
+		}
+		if placed == 4 {
+			return nil
+		}
+		s.do(held, yellowCornersPositionAlgorithm().Expand()...)
+	}
+}
+
+// Step 7: with yellow on top, R' D' R D is repeated until the corner at the
+// front right shows yellow on top; only U is turned between corners. The
+// first two layers look scrambled until every corner is done.
+func (s *beginnerSolve) yellowCornersOrient() error {
+	r := yellowUp(0)
+	oriented := func(v *Cube, i Corner) bool { return v.faceletSolved(cornerFacelet[i][0]) }
+	for attempt := 0; ; attempt++ {
+		if attempt == maxStepAttempts {
+			return errBeginnerStuck
+		}
+		v := s.view(r)
+		if oriented(v, URF) && oriented(v, UFL) && oriented(v, ULB) && oriented(v, UBR) {
+			break
+		}
+		s.turnUntil(r, U, func(v *Cube) bool { return !oriented(v, URF) })
+		for n := 0; !oriented(s.view(r), URF); n++ {
+			if n == 6 {
+				return errBeginnerStuck
+			}
+			s.do(r, yellowCornersOrientAlgorithm().Expand()...)
+		}
+	}
+	// Line the last layer up with the rest of the cube
+	solved := func(v *Cube) bool {
+		return v.piecesSolved([]Corner{URF, UFL, ULB, UBR, DFR, DLF, DBL, DRB},
+			[]Edge{UR, UF, UL, UB, DR, DF, DL, DB, FR, FL, BL, BR})
+	}
+	if !s.turnUntil(r, U, solved) {
+		return errBeginnerStuck
+	}
+	return nil
+}
+
+// beginnerSolver is the registry adapter for SolveBeginnerMethod
+type beginnerSolver struct{}
+
+func init() {
+	Register("beginner", func(Options) Solver { return beginnerSolver{} })
+}
+
+func (beginnerSolver) Name() string { return "beginner" }
+
+func (beginnerSolver) Capabilities() Capabilities {
+	return Capabilities{HumanReadable: true, StepAnnotations: true}
+}
+
+func (s beginnerSolver) Solve(ctx context.Context, c *Cube) (Solution, error) {
+	moves, stages, err := c.SolveBeginnerMethod()
+	if err != nil {
+		return Solution{}, err
+	}
+	if err := checkSolution(c, moves); err != nil {
+		return Solution{}, err
+	}
+	return Solution{Solver: s.Name(), Moves: moves, Stages: stages}, nil
+}
@@ -0,0 +1,510 @@
+package cube
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// CFOP (Fridrich) method solver
+// Solves the way speedcubers do, with the cross on the bottom: the white
+// cross, the four first-two-layer (F2L) pairs of a corner and the edge next
+// to it, then the last layer in two algorithms, OLL to orient it and PLL to
+// permute it. The cross and each pair are found by IDA* search, so they
+// take as few moves as the method allows, and the pairs are solved in
+// whichever order gives the shortest F2L. The last layer is recognised
+// among the 57 OLL and 21 PLL cases (see cfop_cases.go).
+
+// cfopSlot is an F2L slot: the corner and edge that belong there, and the
+// position of the edge in cube coordinates
+type cfopSlot struct {
+	corner Corner
+	edge   Edge
+	faces  [2]Face
+	pos    vec3
+}
+
+var cfopSlots = [4]cfopSlot{
+	{DFR, FR, [2]Face{Front, Right}, vec3{1, 0, 1}},
+	{DLF, FL, [2]Face{Front, Left}, vec3{-1, 0, 1}},
+	{DBL, BL, [2]Face{Back, Left}, vec3{-1, 0, -1}},
+	{DRB, BR, [2]Face{Back, Right}, vec3{1, 0, -1}},
+}
+
+// crossEdges are the edges of the cross, on the D face
+var crossEdges = edgeGroup{DR, DF, DL, DB}
+
+// f2lMoves are the moves F2L searches use: every turn except D, which
+// would only move the cross out of the way and back
+var f2lMoves = []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 12, 13, 14, 15, 16, 17}
+
+// crossCoord encodes where the cross edges are and how they are flipped
+// (0-190079)
+func (cc *CubieCube) crossCoord() int {
+	return cc.edgeGroupCoord(crossEdges)
+}
+
+// edgeGroupCoord encodes where the edges of g are and how they are flipped
+// (0 to g.size()-1)
+func (cc *CubieCube) edgeGroupCoord(g edgeGroup) int {
+	var pos [maxEdgeGroup]int8
+	flips := 0
+	for i, e := range cc.EP {
+		for j, ge := range g {
+			if e == ge {
+				pos[j] = int8(i)
+				flips |= int(cc.EO[i]) << j
+			}
+		}
+	}
+	return placementIndex(pos[:len(g)])<<len(g) | flips
+}
+
+// cornerCoord encodes where corner c is and how it is twisted (0-23)
+func (cc *CubieCube) cornerCoord(c Corner) int {
+	for i, p := range cc.CP {
+		if p == c {
+			return 3*i + int(cc.CO[i])
+		}
+	}
+	return 0
+}
+
+// edgeCoord encodes where edge e is and how it is flipped (0-23)
+func (cc *CubieCube) edgeCoord(e Edge) int {
+	for i, p := range cc.EP {
+		if p == e {
+			return 2*i + int(cc.EO[i])
+		}
+	}
+	return 0
+}
+
+// cfopTables are the move and pruning tables of the CFOP searches. The
+// F2L tables are for the front-right slot only; other slots are solved by
+// looking at the cube from a different side.
+type cfopTables struct {
+	crossMove  []uint32
+	cornerMove [4][]uint16
+	edgeMove   [4][]uint16
+
+	crossPrune  []uint8    // distance to the cross
+	crossCorner []uint8    // the cross and the front-right corner
+	crossEdge   []uint8    // the cross and the front-right edge
+	pairPrune   [4][]uint8 // each slot's corner and edge on their own
+
+	crossHome            int
+	cornerHome, edgeHome [4]int
+}
+
+// cfopCacheMagic identifies the CFOP table cache file layout
+const cfopCacheMagic = "cfop-tables-v1"
+
+var (
+	cfopOnce  sync.Once
+	cfopTable *cfopTables
+)
+
+// loadCFOPTables returns the CFOP tables, generating them on first use.
+// The cross tables are cached on disk like the two-phase tables.
+func loadCFOPTables() *cfopTables {
+	cfopOnce.Do(func() {
+		t := newCFOPTables()
+		path := tableCachePath("cfop.bin")
+		if readTableFile(path, cfopCacheMagic, t.crossFields()...) != nil {
+			t.generateCrossTables()
+			_ = writeTableFile(path, cfopCacheMagic, t.crossFields()...)
+		}
+		cfopTable = t
+	})
+	return cfopTable
+}
+
+// newCFOPTables makes the tables, generating the small ones for the F2L
+// pieces; the cross tables are allocated but left empty
+func newCFOPTables() *cfopTables {
+	nCross := crossEdges.size()
+	solved := SolvedCubie()
+	t := &cfopTables{
+		crossMove:   make([]uint32, nCross*nMoves),
+		crossPrune:  make([]uint8, nCross),
+		crossCorner: make([]uint8, nCross*24),
+		crossEdge:   make([]uint8, nCross*24),
+		crossHome:   solved.crossCoord(),
+	}
+	for s, slot := range cfopSlots {
+		corner := func(cc *CubieCube) int { return cc.cornerCoord(slot.corner) }
+		edge := func(cc *CubieCube) int { return cc.edgeCoord(slot.edge) }
+		t.cornerMove[s] = coordMoveTable(24, corner, allMoveIndices())
+		t.edgeMove[s] = coordMoveTable(24, edge, allMoveIndices())
+		t.cornerHome[s], t.edgeHome[s] = corner(&solved), edge(&solved)
+		t.pairPrune[s] = pruneTableFrom(24, 24, t.cornerMove[s], t.edgeMove[s], f2lMoves,
+			[]int{t.cornerHome[s]*24 + t.edgeHome[s]})
+	}
+	return t
+}
+
+// crossFields lists the cross tables in their cache file order
+func (t *cfopTables) crossFields() []any {
+	return []any{t.crossMove, t.crossPrune, t.crossCorner, t.crossEdge}
+}
+
+func (t *cfopTables) generateCrossTables() {
+	nCross := crossEdges.size()
+	t.crossMove = coordMoveTableOf[uint32](nCross, (*CubieCube).crossCoord, allMoveIndices())
+	t.crossPrune = pruneTableFrom(1, nCross, make([]uint16, nMoves), t.crossMove, allMoveIndices(), []int{t.crossHome})
+	t.crossCorner = pruneTableFrom(nCross, 24, t.crossMove, t.cornerMove[0], f2lMoves,
+		[]int{t.crossHome*24 + t.cornerHome[0]})
+	t.crossEdge = pruneTableFrom(nCross, 24, t.crossMove, t.edgeMove[0], f2lMoves,
+		[]int{t.crossHome*24 + t.edgeHome[0]})
+}
+
+// cfopState is the cube as the CFOP searches track it
+type cfopState struct {
+	cross        int
+	corner, edge [4]int
+}
+
+func newCFOPState(cc *CubieCube) cfopState {
+	s := cfopState{cross: cc.crossCoord()}
+	for i, slot := range cfopSlots {
+		s.corner[i], s.edge[i] = cc.cornerCoord(slot.corner), cc.edgeCoord(slot.edge)
+	}
+	return s
+}
+
+func (t *cfopTables) move(s *cfopState, m int) cfopState {
+	next := cfopState{cross: int(t.crossMove[s.cross*nMoves+m])}
+	for i := range s.corner {
+		next.corner[i] = int(t.cornerMove[i][s.corner[i]*nMoves+m])
+		next.edge[i] = int(t.edgeMove[i][s.edge[i]*nMoves+m])
+	}
+	return next
+}
+
+// cfopSearch is an IDA* search for one step of the method; bound is a lower
+// bound on the moves left that is 0 exactly when the step is done
+type cfopSearch struct {
+	ctx     context.Context
+	tables  *cfopTables
+	moves   []int
+	bound   func(s *cfopState) int
+	path    []int // the step's moves, last first
+	nodes   int
+	stopped bool
+}
+
+// run returns the shortest sequence of moves from s that completes the
+// step, as move indices
+func (search *cfopSearch) run(s cfopState) ([]int, error) {
+	for depth := search.bound(&s); !search.dfs(&s, depth, -1); depth++ {
+		if search.stopped {
+			return nil, search.ctx.Err()
+		}
+	}
+	moves := make([]int, len(search.path))
+	for i, m := range search.path {
+		moves[len(moves)-1-i] = m
+	}
+	return moves, nil
+}
+
+func (search *cfopSearch) dfs(s *cfopState, togo, prev int) bool {
+	search.nodes++
+	if search.nodes&0xfff == 0 && search.ctx.Err() != nil {
+		search.stopped = true
+	}
+	if search.stopped {
+		return false
+	}
+	h := search.bound(s)
+	if togo == 0 || h > togo {
+		return h == 0 && togo == 0
+	}
+	for _, m := range search.moves {
+		if prev >= 0 && redundant(prev, m) {
+			continue
+		}
+		next := search.tables.move(s, m)
+		if search.dfs(&next, togo-1, m) {
+			search.path = append(search.path, m)
+			return true
+		}
+	}
+	return false
+}
+
+// SolveCFOP solves the cube with the CFOP method, returning its stages:
+// the rotations that turn white to the bottom, the cross, four F2L pairs,
+// OLL and PLL. The moves after the rotations are written as the cube is
+// then held, so the last layer algorithms read as they are learnt.
+func (c *Cube) SolveCFOP() (solution []Move, stages []Stage, err error) {
+	return solveCFOP(context.Background(), c, nil)
+}
+
+// cfopSolve holds the cube, held with white on the bottom, as the method
+// works through it
+type cfopSolve struct {
+	ctx    context.Context
+	tables *cfopTables
+	cube   *Cube
+	stages []Stage
+
+	// f2lFaces, if set, are the only faces F2L may turn, as the cube is
+	// held; otherwise it turns any face but D
+	f2lFaces []Face
+	// cost, if set, picks the quickest of the shortest F2L orders
+	cost *CostModel
+}
+
+func solveCFOP(ctx context.Context, c *Cube, cost *CostModel) (solution []Move, stages []Stage, err error) {
+	if err := c.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("cfop: %w", err)
+	}
+	hold, _ := c.holding(func(h *Cube) bool { return h.faces[Down][4] == White })
+	s := &cfopSolve{ctx: ctx, tables: loadCFOPTables(), cube: c.held(hold), cost: cost}
+	s.stages = append(s.stages, inspection(hold, "hold the cube with the white center on the bottom"))
+
+	for _, step := range []func() error{s.cross, s.f2l, s.oll, s.pll} {
+		if err := step(); err != nil {
+			return nil, nil, fmt.Errorf("cfop: %w", err)
+		}
+	}
+	for _, stage := range s.stages {
+		solution = append(solution, stage.Moves...)
+	}
+	return solution, s.stages, nil
+}
+
+// cubie returns the cube, as held, at piece level
+func (s *cfopSolve) cubie() CubieCube {
+	cc, _ := cubieFromFacelets(s.cube.KociembaString())
+	return cc
+}
+
+// add applies a stage's moves, tidied up, and records the stage; skipped
+// says the cube had already reached its goal
+func (s *cfopSolve) add(name, description string, moves []Move, skipped bool) {
+	moves = Simplify(moves)
+	s.cube.ApplyMoves(moves)
+	s.stages = append(s.stages, Stage{Name: name, Description: description, Moves: moves, Skipped: skipped})
+}
+
+func (s *cfopSolve) cross() error {
+	cc := s.cubie()
+	search := &cfopSearch{
+		ctx:    s.ctx,
+		tables: s.tables,
+		moves:  allMoveIndices(),
+		bound:  func(st *cfopState) int { return int(s.tables.crossPrune[st.cross]) },
+	}
+	path, err := search.run(newCFOPState(&cc))
+	if err != nil {
+		return err
+	}
+	col := colorName(s.cube.faces[Down][4])
+	s.add("Cross", "solve the "+col+" edges around the "+col+" center", moveIndexNames(path),
+		s.cube.piecesSolved(nil, crossEdges[:]))
+	return nil
+}
+
+// f2lStep is an F2L pair solved into slot, as the cube is held
+type f2lStep struct {
+	slot  int
+	moves []Move
+}
+
+// f2l tries every order of the four pairs and keeps the shortest, or with
+// a cost model the quickest of the shortest
+func (s *cfopSolve) f2l() error {
+	var best []f2lStep
+	var bestMoves []Move
+	var try func(cube *Cube, solved [4]bool, steps []f2lStep, length int) error
+	try = func(cube *Cube, solved [4]bool, steps []f2lStep, length int) error {
+		if len(steps) == 4 {
+			var moves []Move
+			for _, step := range steps {
+				moves = append(moves, step.moves...)
+			}
+			if best == nil || s.cost.shorter(moves, bestMoves) {
+				best, bestMoves = append([]f2lStep(nil), steps...), moves
+			}
+			return nil
+		}
+		for slot := range cfopSlots {
+			if solved[slot] {
+				continue
+			}
+			moves, err := s.pair(cube, solved, slot)
+			if err != nil {
+				return err
+			}
+			// A longer order can't win, and one as long only on cost
+			if n := length + len(moves); best != nil && (n > len(bestMoves) || n == len(bestMoves) && s.cost == nil) {
+				continue
+			}
+			next := cube.Clone()
+			next.ApplyMoves(moves)
+			solved[slot] = true
+			err = try(next, solved, append(steps, f2lStep{slot, moves}), length+len(moves))
+			solved[slot] = false
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := try(s.cube, [4]bool{}, nil, 0); err != nil {
+		return err
+	}
+
+	for n, step := range best {
+		slot := cfopSlots[step.slot]
+		a, b := colorName(s.cube.faces[slot.faces[0]][4]), colorName(s.cube.faces[slot.faces[1]][4])
+		// An earlier pair's moves may have solved this one too
+		done := s.cube.piecesSolved([]Corner{slot.corner}, []Edge{slot.edge})
+		s.add(fmt.Sprintf("F2L %d", n+1), fmt.Sprintf("pair up the %s-%s corner and edge and insert them", a, b), step.moves, done)
+	}
+	return nil
+}
+
+// pair returns the shortest moves that solve slot's corner and edge into
+// place, keeping the cross and the solved slots. The search always works
+// on the front-right slot, so the cube is turned to bring slot there and
+// the moves found are turned back.
+func (s *cfopSolve) pair(cube *Cube, solved [4]bool, slot int) ([]Move, error) {
+	var view rotation
+	for k := 0; k < 4; k++ {
+		if view = rotationY.times(k); view.apply(cfopSlots[slot].pos) == cfopSlots[0].pos {
+			break
+		}
+	}
+	// Where the solved slots are as the cube is viewed
+	var keep []int
+	for i, ok := range solved {
+		if !ok {
+			continue
+		}
+		for j, other := range cfopSlots {
+			if view.apply(cfopSlots[i].pos) == other.pos {
+				keep = append(keep, j)
+			}
+		}
+	}
+
+	moves := f2lMoves
+	if s.f2lFaces != nil {
+		moves = nil
+		for _, m := range f2lMoves {
+			if slices.Contains(s.f2lFaces, moveFace(view.unheld(Move(moveNames[m])))) {
+				moves = append(moves, m)
+			}
+		}
+	}
+
+	t := s.tables
+	cc, _ := cubieFromFacelets(cube.held(view).KociembaString())
+	search := &cfopSearch{
+		ctx:    s.ctx,
+		tables: t,
+		moves:  moves,
+		bound: func(st *cfopState) int {
+			h := max(t.crossCorner[st.cross*24+st.corner[0]], t.crossEdge[st.cross*24+st.edge[0]])
+			for _, j := range keep {
+				h = max(h, t.pairPrune[j][st.corner[j]*24+st.edge[j]])
+			}
+			return int(h)
+		},
+	}
+	path, err := search.run(newCFOPState(&cc))
+	if err != nil {
+		return nil, err
+	}
+	pair := moveIndexNames(path)
+	for i, m := range pair {
+		pair[i] = view.unheld(m)
+	}
+	return pair, nil
+}
+
+func (s *cfopSolve) oll() error {
+	cc := s.cubie()
+	pre, oll, err := recognizeOLL(&cc)
+	if err != nil {
+		return err
+	}
+	if oll < 0 {
+		s.add("OLL skip", "the last layer is already oriented", nil, true)
+		return nil
+	}
+	moves := append(append([]Move(nil), auf[pre]...), MustParseMoves(ollCases[oll].alg)...)
+	s.add("OLL "+ollCases[oll].name, "orient the last layer", moves, false)
+	return nil
+}
+
+func (s *cfopSolve) pll() error {
+	cc := s.cubie()
+	pre, pll, post, err := recognizePLL(&cc)
+	if err != nil {
+		return err
+	}
+	moves := append([]Move(nil), auf[pre]...)
+	name := "PLL skip"
+	if pll >= 0 {
+		name = "PLL " + pllCases[pll].name
+		moves = append(moves, MustParseMoves(pllCases[pll].alg)...)
+	}
+	s.add(name, "permute the last layer", append(moves, auf[post]...), s.cube.IsSolved())
+	return nil
+}
+
+// allMoveIndices lists all 18 face turns
+func allMoveIndices() []int {
+	moves := make([]int, nMoves)
+	for i := range moves {
+		moves[i] = i
+	}
+	return moves
+}
+
+// moveIndexNames converts move indices to moves
+func moveIndexNames(path []int) []Move {
+	return indexMoves(moveNames[:], path)
+}
+
+// indexMoves converts indices into names to moves
+func indexMoves(names []string, path []int) []Move {
+	out := make([]string, len(path))
+	for i, m := range path {
+		out[i] = names[m]
+	}
+	return MustParseMoves(strings.Join(out, " "))
+}
+
+// cfopSolver is the registry adapter for SolveCFOP
+type cfopSolver struct {
+	cost *CostModel
+}
+
+func init() {
+	Register("cfop", func(opts Options) Solver { return cfopSolver{cost: opts.Cost} })
+}
+
+func (cfopSolver) Name() string { return "cfop" }
+
+func (cfopSolver) Capabilities() Capabilities {
+	return Capabilities{HumanReadable: true, StepAnnotations: true}
+}
+
+func (s cfopSolver) Solve(ctx context.Context, c *Cube) (Solution, error) {
+	moves, stages, err := solveCFOP(ctx, c, s.cost)
+	if err != nil {
+		return Solution{}, err
+	}
+	if err := checkSolution(c, moves); err != nil {
+		return Solution{}, err
+	}
+	return Solution{Solver: s.Name(), Moves: moves, Stages: stages}, nil
+}
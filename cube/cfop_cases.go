@@ -0,0 +1,200 @@
+package cube
+
+import "fmt"
+
+// The last layer cases of CFOP: 57 OLL cases, which orient the last layer,
+// and 21 PLL cases, which then permute it. Each case is recognised by the
+// pattern its algorithm solves, so the algorithm is the only definition of
+// a case there is. Algorithms are written for the last layer on top and
+// leave the first two layers and the centers as they were.
+
+// llCase is a named last layer case and the algorithm that solves it
+type llCase struct {
+	name string
+	alg  string
+}
+
+var ollCases = []llCase{
+	{"1 (Dot)", "R U2 R2 F R F' U2 R' F R F'"},
+	{"2 (Dot)", "F R U R' U' F' Fw R U R' U' Fw'"},
+	{"3 (Dot)", "Fw R U R' U' Fw' U' F R U R' U' F'"},
+	{"4 (Dot)", "Fw R U R' U' Fw' U F R U R' U' F'"},
+	{"5 (Square)", "Lw' U2 L U L' U Lw"},
+	{"6 (Square)", "Rw U2 R' U' R U' Rw'"},
+	{"7 (Small lightning)", "Rw U R' U R U2 Rw'"},
+	{"8 (Small lightning)", "Lw' U' L U' L' U2 Lw"},
+	{"9 (Fish)", "R U R' U' R' F R2 U R' U' F'"},
+	{"10 (Fish)", "R U R' U R' F R F' R U2 R'"},
+	{"11 (Small lightning)", "Rw U R' U R' F R F' R U2 Rw'"},
+	{"12 (Small lightning)", "F R U R' U' F' U F R U R' U' F'"},
+	{"13 (Knight move)", "F U R U' R2 F' R U R U' R'"},
+	{"14 (Knight move)", "R' F R U R' F' R F U' F'"},
+	{"15 (Knight move)", "Lw' U' Lw L' U' L U Lw' U Lw"},
+	{"16 (Knight move)", "Rw U Rw' R U R' U' Rw U' Rw'"},
+	{"17 (Dot)", "R U R' U R' F R F' U2 R' F R F'"},
+	{"18 (Dot)", "Rw U R' U R U2 Rw2 U' R U' R' U2 Rw"},
+	{"19 (Dot)", "M U R U R' U' M' R' F R F'"},
+	{"20 (Dot)", "Rw U R' U' M2 U R U' R' U' M'"},
+	{"21 (H)", "R U2 R' U' R U R' U' R U' R'"},
+	{"22 (Pi)", "R U2 R2 U' R2 U' R2 U2 R"},
+	{"23 (Headlights)", "R2 D' R U2 R' D R U2 R"},
+	{"24 (Chameleon)", "Rw U R' U' Rw' F R F'"},
+	{"25 (Bowtie)", "F' Rw U R' U' Rw' F R"},
+	{"26 (Anti-Sune)", "R U2 R' U' R U' R'"},
+	{"27 (Sune)", "R U R' U R U2 R'"},
+	{"28 (Stealth)", "Rw U R' U' Rw' R U R U' R'"},
+	{"29 (Awkward)", "R U R' U' R U' R' F' U' F R U R'"},
+	{"30 (Awkward)", "F R' F R2 U' R' U' R U R' F2"},
+	{"31 (P shape)", "R' U' F U R U' R' F' R"},
+	{"32 (P shape)", "L U F' U' L' U L F L'"},
+	{"33 (T shape)", "R U R' U' R' F R F'"},
+	{"34 (C shape)", "R U R2 U' R' F R U R U' F'"},
+	{"35 (Fish)", "R U2 R2 F R F' R U2 R'"},
+	{"36 (W shape)", "L' U' L U' L' U L U L F' L' F"},
+	{"37 (Fish)", "F R' F' R U R U' R'"},
+	{"38 (W shape)", "R U R' U R U' R' U' R' F R F'"},
+	{"39 (Big lightning)", "L F' L' U' L U F U' L'"},
+	{"40 (Big lightning)", "R' F R U R' U' F' U R"},
+	{"41 (Awkward)", "R U R' U R U2 R' F R U R' U' F'"},
+	{"42 (Awkward)", "R' U' R U' R' U2 R F R U R' U' F'"},
+	{"43 (P shape)", "F' U' L' U L F"},
+	{"44 (P shape)", "F U R U' R' F'"},
+	{"45 (T shape)", "F R U R' U' F'"},
+	{"46 (C shape)", "R' U' R' F R F' U R"},
+	{"47 (Small L)", "R' U' R' F R F' R' F R F' U R"},
+	{"48 (Small L)", "F R U R' U' R U R' U' F'"},
+	{"49 (Small L)", "Rw U' Rw2 U Rw2 U Rw2 U' Rw"},
+	{"50 (Small L)", "Rw' U Rw2 U' Rw2 U' Rw2 U Rw'"},
+	{"51 (Line)", "F U R U' R' U R U' R' F'"},
+	{"52 (Line)", "R U R' U R U' B U' B' R'"},
+	{"53 (Small L)", "Lw' U2 L U L' U' L U L' U Lw"},
+	{"54 (Small L)", "Rw U2 R' U' R U R' U' R U' Rw'"},
+	{"55 (Line)", "R' F R U R U' R2 F' R2 U' R' U R U R'"},
+	{"56 (Line)", "Rw' U' Rw U' R' U R U' R' U R Rw' U Rw"},
+	{"57 (Mummy)", "R U R' U' M' U R U' Rw'"},
+}
+
+var pllCases = []llCase{
+	{"Aa", "x R' U R' D2 R U' R' D2 R2 x'"},
+	{"Ab", "x R2 D2 R U R' D2 R U' R x'"},
+	{"E", "x' R U' R' D R U R' D' R U R' D R U' R' D' x"},
+	{"F", "R' U' F' R U R' U' R' F R2 U' R' U' R U R' U R"},
+	{"Ga", "R2 U R' U R' U' R U' R2 U' D R' U R D'"},
+	{"Gb", "R' U' R U D' R2 U R' U R U' R U' R2 D"},
+	{"Gc", "R2 U' R U' R U R' U R2 U D' R U' R' D"},
+	{"Gd", "R U R' U' D R2 U' R U' R' U R' U R2 D'"},
+	{"H", "M2 U M2 U2 M2 U M2"},
+	{"Ja", "R' U L' U2 R U' R' U2 R L U'"},
+	{"Jb", "R U R' F' R U R' U' R' F R2 U' R'"},
+	{"Na", "R U R' U R U R' F' R U R' U' R' F R2 U' R' U2 R U' R'"},
+	{"Nb", "R' U R U' R' F' U' F R U R' F R' F' R U' R"},
+	{"Ra", "R U' R' U' R U R D R' U' R D' R' U2 R'"},
+	{"Rb", "R2 F R U R U' R' F' R U2 R' U2 R"},
+	{"T", "R U R' U' R' F R2 U' R' U' R U R' F'"},
+	{"Ua", "M2 U M U2 M' U M2"},
+	{"Ub", "M2 U' M U2 M' U' M2"},
+	{"V", "R' U R' U' R D' R' D R' U D' R2 U' R2 D R2"},
+	{"Y", "F R U' R' U' R U R' F' R U R' U' R' F R F'"},
+	{"Z", "M' U M2 U M2 U M' U2 M2"},
+}
+
+// ollPattern is the orientation of the last layer: the twist of the corners
+// and flip of the edges in the U layer, which depends only on how the
+// pieces there are oriented and not on which pieces they are
+type ollPattern [8]int8
+
+func ollPatternOf(cc *CubieCube) ollPattern {
+	var p ollPattern
+	for i := URF; i <= UBR; i++ {
+		p[i] = cc.CO[i]
+	}
+	for i := UR; i <= UB; i++ {
+		p[4+int(i)] = cc.EO[i]
+	}
+	return p
+}
+
+// pllPattern is the permutation of the last layer's corners and edges
+type pllPattern [8]int8
+
+func pllPatternOf(cc *CubieCube) pllPattern {
+	var p pllPattern
+	for i := URF; i <= UBR; i++ {
+		p[i] = int8(cc.CP[i])
+	}
+	for i := UR; i <= UB; i++ {
+		p[4+int(i)] = int8(cc.EP[i])
+	}
+	return p
+}
+
+// auf[n] turns U by n quarter turns, the "adjust U face" moves before and
+// after the last layer algorithms
+var auf = [4][]Move{nil, {U}, {U2}, {Ui}}
+
+// ollTable maps the pattern each OLL algorithm solves to its case
+var ollTable = func() map[ollPattern]int {
+	table := make(map[ollPattern]int, len(ollCases))
+	for i, c := range ollCases {
+		cc := SolvedCubie()
+		cc.ApplyMoves(Invert(MustParseMoves(c.alg)))
+		table[ollPatternOf(&cc)] = i
+	}
+	return table
+}()
+
+// pllEntry is a PLL case and the turn of U that finishes it
+type pllEntry struct {
+	pll, auf int
+}
+
+// pllTable maps the pattern each PLL algorithm, followed by each turn of
+// U, solves to its case
+var pllTable = func() map[pllPattern]pllEntry {
+	table := make(map[pllPattern]pllEntry, 4*len(pllCases))
+	for i, c := range pllCases {
+		for a := 0; a < 4; a++ {
+			cc := SolvedCubie()
+			cc.ApplyMoves(Invert(auf[a]))
+			cc.ApplyMoves(Invert(MustParseMoves(c.alg)))
+			table[pllPatternOf(&cc)] = pllEntry{i, a}
+		}
+	}
+	return table
+}()
+
+// recognizeOLL returns the turn of U and the OLL case that orient the last
+// layer of cc, or -1 if it is already oriented
+func recognizeOLL(cc *CubieCube) (pre, oll int, err error) {
+	for a := 0; a < 4; a++ {
+		v := *cc
+		v.ApplyMoves(auf[a])
+		p := ollPatternOf(&v)
+		if p == (ollPattern{}) {
+			return 0, -1, nil
+		}
+		if i, ok := ollTable[p]; ok {
+			return a, i, nil
+		}
+	}
+	return 0, 0, fmt.Errorf("no OLL case matches %v", ollPatternOf(cc))
+}
+
+// recognizePLL returns the turns of U before and after the PLL case that
+// solve the oriented last layer of cc, or -1 for the case if turning U is
+// all it needs
+func recognizePLL(cc *CubieCube) (pre, pll, post int, err error) {
+	solved := SolvedCubie()
+	for a := 0; a < 4; a++ {
+		v := *cc
+		v.ApplyMoves(auf[a])
+		p := pllPatternOf(&v)
+		if p == pllPatternOf(&solved) {
+			return a, -1, 0, nil
+		}
+		if e, ok := pllTable[p]; ok {
+			return a, e.pll, e.auf, nil
+		}
+	}
+	return 0, 0, 0, fmt.Errorf("no PLL case matches %v", pllPatternOf(cc))
+}
@@ -0,0 +1,236 @@
+package cube
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestSolveCFOP(t *testing.T) {
+	s := NewScrambler(1)
+	for i := 0; i < 30; i++ {
+		scramble := s.RandomMoves(25)
+		c := NewCube()
+		c.ApplyMoves(scramble)
+
+		solution, stages, err := c.SolveCFOP()
+		if err != nil {
+			t.Fatalf("%s: %v", FormatMoves(scramble), err)
+		}
+		var names []string
+		for _, stage := range stages {
+			names = append(names, strings.Fields(stage.Name)[0])
+		}
+		if got := strings.Join(names, " "); got != "Inspection Cross F2L F2L F2L F2L OLL PLL" {
+			t.Fatalf("%s: stages %s", FormatMoves(scramble), got)
+		}
+
+		// White is on top, so the cube is turned over, and the cross and
+		// pairs are solved on the D face
+		c.ApplyMoves(stages[0].Moves)
+		if c.faces[Down][4] != White {
+			t.Fatalf("%s: %s left %s on the bottom", FormatMoves(scramble), FormatMoves(stages[0].Moves), c.faces[Down][4])
+		}
+		c.ApplyMoves(stages[1].Moves)
+		if !c.piecesSolved(nil, []Edge{DR, DF, DL, DB}) {
+			t.Fatalf("%s: cross %s left %s", FormatMoves(scramble), FormatMoves(stages[1].Moves), c.KociembaString())
+		}
+		for _, stage := range stages[2:6] {
+			// A pair is skipped when an earlier one's moves solved it
+			if stage.Skipped != (len(stage.Moves) == 0) {
+				t.Fatalf("%s: %s %s, skipped %v", FormatMoves(scramble), stage.Name, FormatMoves(stage.Moves), stage.Skipped)
+			}
+			c.ApplyMoves(stage.Moves)
+		}
+		if !c.piecesSolved([]Corner{DFR, DLF, DBL, DRB}, []Edge{DR, DF, DL, DB, FR, FL, BL, BR}) {
+			t.Fatalf("%s: F2L left %s", FormatMoves(scramble), c.KociembaString())
+		}
+		for _, stage := range stages[6:] {
+			c.ApplyMoves(stage.Moves)
+		}
+		if !c.IsSolved() {
+			t.Fatalf("%s: solution %s left %s", FormatMoves(scramble), FormatMoves(solution), c.KociembaString())
+		}
+	}
+}
+
+func TestSolveCFOPAnyOrientation(t *testing.T) {
+	c := NewCubeScheme(Japanese)
+	c.ApplyMoves(MustParseMoves("x R U' z F2 D y B L2 U"))
+	solution, _, err := c.SolveCFOP()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.ApplyMoves(solution)
+	if !c.IsSolved() {
+		t.Fatalf("solution %s left %s", FormatMoves(solution), c.KociembaString())
+	}
+}
+
+func TestSolveCFOPNamesCases(t *testing.T) {
+	// A T perm from a solved cube, white on the bottom
+	c := NewCube()
+	c.ApplyMoves(MustParseMoves("x2"))
+	c.ApplyMoves(Invert(MustParseMoves(pllCases[15].alg)))
+	_, stages, err := c.SolveCFOP()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, stage := range stages[:7] {
+		if len(stage.Moves) != 0 || !stage.Skipped {
+			t.Errorf("%s: %s, want it skipped", stage.Name, FormatMoves(stage.Moves))
+		}
+	}
+	if stages[7].Skipped {
+		t.Error("PLL T: skipped")
+	}
+	if stages[6].Name != "OLL skip" || stages[7].Name != "PLL T" {
+		t.Errorf("got %q and %q, want OLL skip and PLL T", stages[6].Name, stages[7].Name)
+	}
+	// The algorithm reads as it is learnt, white on the bottom
+	if got, want := FormatMoves(stages[7].Moves), FormatMoves(MustParseMoves(pllCases[15].alg)); got != want {
+		t.Errorf("PLL T: %s, want %s", got, want)
+	}
+
+	// A Sune ends with R' and the T perm starts with R; through a chain,
+	// as users get them, both still read as learnt
+	c.ApplyMoves(Invert(MustParseMoves(ollCases[26].alg)))
+	chain, err := NewChain([]string{"cfop"}, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	solution, err := chain.Solve(context.Background(), c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, want := range []Stage{
+		{Name: "OLL 27 (Sune)", Moves: MustParseMoves(ollCases[26].alg)},
+		{Name: "PLL T", Moves: MustParseMoves(pllCases[15].alg)},
+	} {
+		if got := solution.Stages[6+i]; got.Name != want.Name || FormatMoves(got.Moves) != FormatMoves(want.Moves) {
+			t.Errorf("got %s: %s, want %s: %s", got.Name, FormatMoves(got.Moves), want.Name, FormatMoves(want.Moves))
+		}
+	}
+}
+
+// firstTwoLayersSolved reports whether everything but the U layer is home
+func firstTwoLayersSolved(cc *CubieCube) bool {
+	for i := DFR; i <= DRB; i++ {
+		if cc.CP[i] != i || cc.CO[i] != 0 {
+			return false
+		}
+	}
+	for i := DR; i <= BR; i++ {
+		if cc.EP[i] != i || cc.EO[i] != 0 {
+			return false
+		}
+	}
+	solved := SolvedCubie()
+	return cc.Centers == solved.Centers
+}
+
+func TestLastLayerAlgorithms(t *testing.T) {
+	for _, c := range ollCases {
+		cc := SolvedCubie()
+		cc.ApplyMoves(MustParseMoves(c.alg))
+		if !firstTwoLayersSolved(&cc) {
+			t.Errorf("OLL %s: %s disturbs the first two layers", c.name, c.alg)
+		}
+	}
+	for _, c := range pllCases {
+		cc := SolvedCubie()
+		cc.ApplyMoves(MustParseMoves(c.alg))
+		if !firstTwoLayersSolved(&cc) {
+			t.Errorf("PLL %s: %s disturbs the first two layers", c.name, c.alg)
+		}
+		if ollPatternOf(&cc) != (ollPattern{}) {
+			t.Errorf("PLL %s: %s changes the orientation of the last layer", c.name, c.alg)
+		}
+	}
+}
+
+func TestRecognizeEveryLastLayer(t *testing.T) {
+	// Every orientation of the last layer is one OLL case, seen from one of
+	// four sides
+	if len(ollTable) != len(ollCases) {
+		t.Errorf("%d OLL cases share patterns", len(ollCases)-len(ollTable))
+	}
+	seen := map[int]bool{}
+	for co := 0; co < 81; co++ {
+		for eo := 0; eo < 16; eo++ {
+			cc := SolvedCubie()
+			twist, flips := 0, 0
+			for i := 0; i < 4; i++ {
+				cc.CO[i] = int8(co / pow(3, i) % 3)
+				cc.EO[i] = int8(eo >> i & 1)
+				twist += int(cc.CO[i])
+				flips += int(cc.EO[i])
+			}
+			if twist%3 != 0 || flips%2 != 0 {
+				continue
+			}
+			pre, oll, err := recognizeOLL(&cc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			seen[oll] = true
+			cc.ApplyMoves(auf[pre])
+			if oll >= 0 {
+				cc.ApplyMoves(MustParseMoves(ollCases[oll].alg))
+			}
+			if ollPatternOf(&cc) != (ollPattern{}) {
+				t.Fatalf("OLL %s did not orient the last layer", ollCases[oll].name)
+			}
+		}
+	}
+	if len(seen) != len(ollCases)+1 {
+		t.Errorf("recognised %d OLL cases, want %d and the solved case", len(seen)-1, len(ollCases))
+	}
+
+	// Every permutation of the last layer is one PLL case, up to turns of U
+	seen = map[int]bool{}
+	corners := permutations(4)
+	for _, cp := range corners {
+		for _, ep := range permutations(4) {
+			cc := SolvedCubie()
+			for i := 0; i < 4; i++ {
+				cc.CP[i] = Corner(cp[i])
+				cc.EP[i] = Edge(ep[i])
+			}
+			if permParity(cc.CP[:]) != permParity(cc.EP[:]) {
+				continue
+			}
+			pre, pll, post, err := recognizePLL(&cc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			seen[pll] = true
+			cc.ApplyMoves(auf[pre])
+			if pll >= 0 {
+				cc.ApplyMoves(MustParseMoves(pllCases[pll].alg))
+			}
+			cc.ApplyMoves(auf[post])
+			if cc != SolvedCubie() {
+				t.Fatalf("PLL %v did not solve the last layer", pll)
+			}
+		}
+	}
+	if len(seen) != len(pllCases)+1 {
+		t.Errorf("recognised %d PLL cases, want %d and the solved case", len(seen)-1, len(pllCases))
+	}
+}
+
+// permutations lists every permutation of 0..n-1
+func permutations(n int) [][]int {
+	if n == 0 {
+		return [][]int{{}}
+	}
+	var out [][]int
+	for _, p := range permutations(n - 1) {
+		for i := 0; i <= len(p); i++ {
+			q := append(append(append([]int{}, p[:i]...), n-1), p[i:]...)
+			out = append(out, q)
+		}
+	}
+	return out
+}
@@ -0,0 +1,154 @@
+// Package cube implements a 3x3x3 Rubik's Cube: the sticker state, move
+// application, move notation and solvers.
+package cube
+
+import "strings"
+
+// Color represents a cube face color
+type Color int
+
+const (
+	White Color = iota
+	Red
+	Blue
+	Orange
+	Green
+	Yellow
+)
+
+func (c Color) String() string {
+	return [...]string{"W", "R", "B", "O", "G", "Y"}[c]
+}
+
+// Face identifies one side of the cube. The values double as indices into
+// the sticker array, so they are also the order faces are stored in.
+type Face int
+
+// Face indices
+const (
+	Front Face = iota
+	Right
+	Back
+	Left
+	Up
+	Down
+)
+
+func (f Face) String() string {
+	return [...]string{"F", "R", "B", "L", "U", "D"}[f]
+}
+
+// Cube represents the Rubik's Cube state
+// Faces: Front, Right, Back, Left, Up, Down
+//
+// Stickers on each face are numbered row by row as seen when looking
+// straight at that face (Up is viewed with Back at the top, Down with Front
+// at the top):
+//
+//	0 1 2
+//	3 4 5
+//	6 7 8
+type Cube struct {
+	faces  [6][9]Color // 6 faces, 9 stickers each
+	scheme ColorScheme // colors of the solved cube; the zero value means Western
+}
+
+// NewCube creates a solved cube in the Western color scheme:
+// Front=Green, Right=Red, Back=Blue, Left=Orange, Up=White, Down=Yellow
+func NewCube() *Cube {
+	return NewCubeScheme(Western)
+}
+
+// NewCubeScheme creates a solved cube colored by scheme s, which should
+// pass ColorScheme.Validate
+func NewCubeScheme(s ColorScheme) *Cube {
+	c := &Cube{scheme: s}
+	for face := 0; face < 6; face++ {
+		for sticker := 0; sticker < 9; sticker++ {
+			c.faces[face][sticker] = s[face]
+		}
+	}
+	return c
+}
+
+// Scheme returns the color scheme of the cube
+func (c *Cube) Scheme() ColorScheme {
+	if c.scheme == (ColorScheme{}) {
+		return Western
+	}
+	return c.scheme
+}
+
+// Clone returns an independent copy of the cube
+func (c *Cube) Clone() *Cube {
+	clone := *c
+	return &clone
+}
+
+// Sticker returns the color of sticker i (0-8) on face f
+func (c *Cube) Sticker(f Face, i int) Color {
+	return c.faces[f][i]
+}
+
+// SetSticker sets the color of sticker i (0-8) on face f
+func (c *Cube) SetSticker(f Face, i int, color Color) {
+	c.faces[f][i] = color
+}
+
+// Face returns the nine stickers of face f
+func (c *Cube) Face(f Face) [9]Color {
+	return c.faces[f]
+}
+
+// KociembaString converts our cube representation to Kociemba format:
+// 54 face letters for the U, R, F, D, L and B faces, 9 stickers each. Each
+// color is named after the face whose center it is, so the cube may be in
+// any color scheme and held any way up.
+func (c *Cube) KociembaString() string {
+	return c.faceletsBy(c.centers())
+}
+
+// centers returns the center colors, which are the scheme the cube shows
+// as it is held
+func (c *Cube) centers() ColorScheme {
+	var s ColorScheme
+	for f := range s {
+		s[f] = c.faces[f][4]
+	}
+	return s
+}
+
+// faceletsBy spells the cube out as a Kociemba facelet string, naming each
+// color by the face it belongs to in scheme s. Colors not in s become '?'.
+func (c *Cube) faceletsBy(s ColorScheme) string {
+	var result strings.Builder
+	for k := 0; k < 54; k++ {
+		if f, ok := s.face(c.facelet(k)); ok {
+			result.WriteByte(f.String()[0])
+		} else {
+			result.WriteByte('?')
+		}
+	}
+	return result.String()
+}
+
+// kociembaFaceOrder lists our faces in Kociemba string order
+var kociembaFaceOrder = [6]Face{Up, Right, Front, Down, Left, Back}
+
+// facelet returns the sticker at index k (0-53) of the Kociemba string
+func (c *Cube) facelet(k int) Color {
+	return c.faces[kociembaFaceOrder[k/9]][k%9]
+}
+
+// IsSolved reports whether every face is a single color. How the cube is
+// held doesn't matter.
+func (c *Cube) IsSolved() bool {
+	for _, face := range c.faces {
+		for _, col := range face {
+			if col != face[4] {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,52 @@
+package cube
+
+import "testing"
+
+func TestSolvedKociembaString(t *testing.T) {
+	c := NewCube()
+	want := "UUUUUUUUURRRRRRRRRFFFFFFFFFDDDDDDDDDLLLLLLLLLBBBBBBBBB"
+	if got := c.KociembaString(); got != want {
+		t.Fatalf("solved cube string = %s, want %s", got, want)
+	}
+	if !c.IsSolved() {
+		t.Fatal("new cube is not solved")
+	}
+}
+
+func TestMoveOrder(t *testing.T) {
+	for _, move := range AllMoves {
+		c := NewCube()
+		for i := 0; i < 4; i++ {
+			c.ApplyMove(move)
+		}
+		if !c.IsSolved() {
+			t.Errorf("%s applied four times does not restore the cube", move)
+		}
+	}
+}
+
+func TestInvertSolves(t *testing.T) {
+	// R U R' U R U2 R' (Sune)
+	scramble := []Move{R, U, Ri, U, R, U, U, Ri}
+	c := NewCube()
+	c.ApplyMoves(scramble)
+	if c.IsSolved() {
+		t.Fatal("scramble left the cube solved")
+	}
+	c.ApplyMoves(Invert(scramble))
+	if !c.IsSolved() {
+		t.Fatalf("move reversal did not solve the cube: %s", c.KociembaString())
+	}
+}
+
+func TestIsSolvedInAnyOrientation(t *testing.T) {
+	c := NewCube()
+	c.ApplyMoves(MustParseMoves("x y2 z'"))
+	if !c.IsSolved() {
+		t.Fatal("rotated solved cube is not solved")
+	}
+	c.ApplyMoves(MustParseMoves("M"))
+	if c.IsSolved() {
+		t.Fatal("cube with a turned slice is solved")
+	}
+}
@@ -0,0 +1,381 @@
+package cube
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The cubie level describes the cube by where each corner and edge piece
+// sits and how it is twisted, rather than by sticker colors. Solvers work on
+// this representation (and on coordinates derived from it) because it is
+// compact and moves become simple permutation products.
+
+// Corner identifies a corner position, or the piece that belongs there
+type Corner int8
+
+// Corner positions, in Kociemba's order
+const (
+	URF Corner = iota
+	UFL
+	ULB
+	UBR
+	DFR
+	DLF
+	DBL
+	DRB
+)
+
+func (c Corner) String() string {
+	return [...]string{"URF", "UFL", "ULB", "UBR", "DFR", "DLF", "DBL", "DRB"}[c]
+}
+
+// Edge identifies an edge position, or the piece that belongs there
+type Edge int8
+
+// Edge positions, in Kociemba's order
+const (
+	UR Edge = iota
+	UF
+	UL
+	UB
+	DR
+	DF
+	DL
+	DB
+	FR
+	FL
+	BL
+	BR
+)
+
+func (e Edge) String() string {
+	return [...]string{"UR", "UF", "UL", "UB", "DR", "DF", "DL", "DB", "FR", "FL", "BL", "BR"}[e]
+}
+
+// Facelet indices into a Kociemba string (U1..U9, R1..R9, F1..F9, D1..D9,
+// L1..L9, B1..B9) for the stickers of each corner and edge position. The
+// first facelet of every corner and edge is the reference facelet used to
+// measure its orientation.
+var (
+	cornerFacelet = [8][3]int{
+		{8, 9, 20},   // URF: U9 R1 F3
+		{6, 18, 38},  // UFL: U7 F1 L3
+		{0, 36, 47},  // ULB: U1 L1 B3
+		{2, 45, 11},  // UBR: U3 B1 R3
+		{29, 26, 15}, // DFR: D3 F9 R7
+		{27, 44, 24}, // DLF: D1 L9 F7
+		{33, 53, 42}, // DBL: D7 B9 L7
+		{35, 17, 51}, // DRB: D9 R9 B7
+	}
+	edgeFacelet = [12][2]int{
+		{5, 10},  // UR: U6 R2
+		{7, 19},  // UF: U8 F2
+		{3, 37},  // UL: U4 L2
+		{1, 46},  // UB: U2 B2
+		{32, 16}, // DR: D6 R8
+		{28, 25}, // DF: D2 F8
+		{30, 43}, // DL: D4 L8
+		{34, 52}, // DB: D8 B8
+		{23, 12}, // FR: F6 R4
+		{21, 41}, // FL: F4 L6
+		{50, 39}, // BL: B6 L4
+		{48, 14}, // BR: B4 R6
+	}
+)
+
+// Sticker is a sticker's place on the cube: its face, and its index on
+// that face as in Cube.Face
+type Sticker struct {
+	Face  Face
+	Index int
+}
+
+// Stickers returns the three stickers of corner position c, reference
+// sticker first and then clockwise
+func (c Corner) Stickers() [3]Sticker {
+	var out [3]Sticker
+	for n, k := range cornerFacelet[c] {
+		out[n] = Sticker{kociembaFaceOrder[k/9], k % 9}
+	}
+	return out
+}
+
+// Stickers returns the two stickers of edge position e, reference sticker
+// first
+func (e Edge) Stickers() [2]Sticker {
+	var out [2]Sticker
+	for n, k := range edgeFacelet[e] {
+		out[n] = Sticker{kociembaFaceOrder[k/9], k % 9}
+	}
+	return out
+}
+
+// Face letters of each corner and edge piece, reference facelet first
+var (
+	cornerColor = [8][3]byte{
+		{'U', 'R', 'F'}, {'U', 'F', 'L'}, {'U', 'L', 'B'}, {'U', 'B', 'R'},
+		{'D', 'F', 'R'}, {'D', 'L', 'F'}, {'D', 'B', 'L'}, {'D', 'R', 'B'},
+	}
+	edgeColor = [12][2]byte{
+		{'U', 'R'}, {'U', 'F'}, {'U', 'L'}, {'U', 'B'},
+		{'D', 'R'}, {'D', 'F'}, {'D', 'L'}, {'D', 'B'},
+		{'F', 'R'}, {'F', 'L'}, {'B', 'L'}, {'B', 'R'},
+	}
+)
+
+// CubieCube is the cube at piece level. CP[i] is the corner occupying
+// position i and CO[i] its twist (0-2, clockwise); likewise EP/EO for edges
+// with a flip of 0 or 1. Centers[f] is the face whose center sits on face
+// f, which only changes with slice moves and whole-cube rotations.
+//
+// Pieces are identified by their colors in the cube's color scheme, so
+// rotating the whole cube moves every piece and center.
+type CubieCube struct {
+	CP      [8]Corner
+	CO      [8]int8
+	EP      [12]Edge
+	EO      [12]int8
+	Centers [6]Face
+}
+
+// SolvedCubie returns the identity cubie cube
+func SolvedCubie() CubieCube {
+	var cc CubieCube
+	for i := range cc.CP {
+		cc.CP[i] = Corner(i)
+	}
+	for i := range cc.EP {
+		cc.EP[i] = Edge(i)
+	}
+	for f := range cc.Centers {
+		cc.Centers[f] = Face(f)
+	}
+	return cc
+}
+
+// Multiply returns the cube reached by applying b to a. With b the cubie
+// cube of a move sequence, that is a with the sequence applied.
+func (a *CubieCube) Multiply(b *CubieCube) CubieCube {
+	var r CubieCube
+	for i := 0; i < 8; i++ {
+		r.CP[i] = a.CP[b.CP[i]]
+		r.CO[i] = (a.CO[b.CP[i]] + b.CO[i]) % 3
+	}
+	for i := 0; i < 12; i++ {
+		r.EP[i] = a.EP[b.EP[i]]
+		r.EO[i] = (a.EO[b.EP[i]] + b.EO[i]) % 2
+	}
+	for f := 0; f < 6; f++ {
+		r.Centers[f] = a.Centers[b.Centers[f]]
+	}
+	return r
+}
+
+// Inverse returns the cube that undoes cc: cc.Multiply(&inv) is solved
+func (cc *CubieCube) Inverse() CubieCube {
+	var inv CubieCube
+	for i, p := range cc.CP {
+		inv.CP[p] = Corner(i)
+		inv.CO[p] = (3 - cc.CO[i]) % 3
+	}
+	for i, p := range cc.EP {
+		inv.EP[p] = Edge(i)
+		inv.EO[p] = cc.EO[i]
+	}
+	for f, g := range cc.Centers {
+		inv.Centers[g] = Face(f)
+	}
+	return inv
+}
+
+// ApplyMove applies a single move of any kind. Like Cube.ApplyMove it
+// accepts non-canonical spellings and ignores moves it doesn't know.
+func (cc *CubieCube) ApplyMove(m Move) {
+	mc, ok := moveCubies[m]
+	if !ok {
+		canonical, err := ParseMove(string(m))
+		if err != nil {
+			return
+		}
+		mc = moveCubies[canonical]
+	}
+	*cc = cc.Multiply(&mc)
+}
+
+// ApplyMoves applies a sequence of moves
+func (cc *CubieCube) ApplyMoves(moves []Move) {
+	for _, m := range moves {
+		cc.ApplyMove(m)
+	}
+}
+
+// IsSolved reports whether every face is one color, whichever way round
+// the cube is held
+func (cc *CubieCube) IsSolved() bool {
+	for _, r := range rotationCubies {
+		if *cc == r {
+			return true
+		}
+	}
+	return false
+}
+
+// Cubie returns the cube at piece level, identifying pieces by their
+// colors in the cube's color scheme. It fails if the stickers don't make a
+// cube that can be solved by turning faces (see Validate), or if they don't
+// match the scheme.
+func (c *Cube) Cubie() (CubieCube, error) {
+	if err := c.Validate(); err != nil {
+		return CubieCube{}, err
+	}
+	scheme := c.Scheme()
+	cc, err := cubieFromFacelets(c.faceletsBy(scheme))
+	if err != nil {
+		return cc, fmt.Errorf("%v in the %s color scheme", err, scheme)
+	}
+	for f := range cc.Centers {
+		cc.Centers[f], _ = scheme.face(c.faces[f][4])
+	}
+	centers := c.centers()
+	if _, ok := NewCubeScheme(scheme).holding(func(h *Cube) bool { return h.centers() == centers }); !ok {
+		return cc, fmt.Errorf("centers %s are not the %s color scheme held some way up", centers, scheme)
+	}
+	return cc, nil
+}
+
+// cubieFromFacelets reads the corners and edges of a Kociemba facelet
+// string. The centers are left where they belong.
+func cubieFromFacelets(facelets string) (CubieCube, error) {
+	cc := SolvedCubie()
+	for i := URF; i <= DRB; i++ {
+		piece, twist, ok := cornerPiece(facelets, i)
+		if !ok {
+			return cc, fmt.Errorf("corner at %s has impossible colors", i)
+		}
+		cc.CP[i], cc.CO[i] = piece, twist
+	}
+	for i := UR; i <= BR; i++ {
+		piece, flip, ok := edgePiece(facelets, i)
+		if !ok {
+			return cc, fmt.Errorf("edge at %s has impossible colors", i)
+		}
+		cc.EP[i], cc.EO[i] = piece, flip
+	}
+	return cc, nil
+}
+
+// ParseFacelets reads a cube from a Kociemba facelet string, the 54 face
+// letters that KociembaString writes, painted in the Western color scheme.
+// The cube must be solvable.
+func ParseFacelets(facelets string) (*Cube, error) {
+	facelets = strings.ToUpper(strings.TrimSpace(facelets))
+	if len(facelets) != 54 {
+		return nil, fmt.Errorf("facelets: got %d letters, want 54", len(facelets))
+	}
+	for i, f := range kociembaFaceOrder {
+		if center := facelets[9*i+4]; center != "URFDLB"[i] {
+			return nil, fmt.Errorf("facelets: the %s center is %c", f, center)
+		}
+	}
+	cc, err := cubieFromFacelets(facelets)
+	if err != nil {
+		return nil, fmt.Errorf("facelets: %v", err)
+	}
+	c := cc.Cube()
+	if err := c.Validate(); err != nil {
+		return nil, fmt.Errorf("facelets: %w", err)
+	}
+	return c, nil
+}
+
+// Cube paints the cubie cube's stickers in the Western color scheme
+func (cc *CubieCube) Cube() *Cube {
+	return cc.Paint(Western)
+}
+
+// Paint returns the cubie cube's stickers colored by scheme s
+func (cc *CubieCube) Paint(s ColorScheme) *Cube {
+	c := &Cube{scheme: s}
+	paint := func(k int, letter byte) {
+		face := Face(strings.IndexByte("FRBLUD", letter))
+		c.faces[kociembaFaceOrder[k/9]][k%9] = s[face]
+	}
+	for f, home := range cc.Centers {
+		c.faces[f][4] = s[home]
+	}
+	for i := 0; i < 8; i++ {
+		for n := 0; n < 3; n++ {
+			paint(cornerFacelet[i][(n+int(cc.CO[i]))%3], cornerColor[cc.CP[i]][n])
+		}
+	}
+	for i := 0; i < 12; i++ {
+		for n := 0; n < 2; n++ {
+			paint(edgeFacelet[i][(n+int(cc.EO[i]))%2], edgeColor[cc.EP[i]][n])
+		}
+	}
+	return c
+}
+
+// moveCubies holds the cubie cube of every canonical move, read off the
+// sticker permutations so the two models always agree
+var moveCubies = func() map[Move]CubieCube {
+	cubies := make(map[Move]CubieCube, len(moveTable))
+	for m := range moveTable {
+		c := NewCube()
+		c.ApplyMove(m)
+		cc, err := c.Cubie()
+		if err != nil {
+			panic("cube: no cubie cube for " + string(m) + ": " + err.Error())
+		}
+		cubies[m] = cc
+	}
+	return cubies
+}()
+
+// rotationCubies holds the 24 whole-cube rotations, i.e. every way a
+// solved cube can be held
+var rotationCubies = func() []CubieCube {
+	rotations := []CubieCube{SolvedCubie()}
+	for i := 0; i < len(rotations); i++ {
+		for _, m := range []Move{"x", "y"} {
+			mc := moveCubies[m]
+			next := rotations[i].Multiply(&mc)
+			found := false
+			for _, r := range rotations {
+				found = found || r == next
+			}
+			if !found {
+				rotations = append(rotations, next)
+			}
+		}
+	}
+	return rotations
+}()
+
+// permParity returns 0 for even and 1 for odd permutations
+func permParity[T Corner | Edge](p []T) int {
+	parity := 0
+	for i := 0; i < len(p); i++ {
+		for j := i + 1; j < len(p); j++ {
+			if p[i] > p[j] {
+				parity ^= 1
+			}
+		}
+	}
+	return parity
+}
+
+// permRank returns the lexicographic rank of a permutation of 0..n-1
+func permRank[T Corner | Edge](p []T) int {
+	rank := 0
+	for i := 0; i < len(p); i++ {
+		smaller := 0
+		for j := i + 1; j < len(p); j++ {
+			if p[j] < p[i] {
+				smaller++
+			}
+		}
+		rank = rank*(len(p)-i) + smaller
+	}
+	return rank
+}
@@ -0,0 +1,98 @@
+package cube
+
+import (
+	"slices"
+	"testing"
+)
+
+// allCanonicalMoves lists every move in the move table, in a fixed order
+func allCanonicalMoves() []Move {
+	var moves []Move
+	for m := range moveTable {
+		moves = append(moves, m)
+	}
+	slices.Sort(moves)
+	return moves
+}
+
+func TestCubieMovesMatchStickers(t *testing.T) {
+	moves := allCanonicalMoves()
+	s := NewScrambler(10)
+	for i := 0; i < 100; i++ {
+		c, cc := NewCube(), SolvedCubie()
+		for n := 0; n < 20; n++ {
+			m := moves[s.rng.IntN(len(moves))]
+			c.ApplyMove(m)
+			cc.ApplyMove(m)
+		}
+		if got := cc.Cube(); got.faces != c.faces {
+			t.Fatalf("cubie cube %+v paints %s, want %s", cc, got.KociembaString(), c.KociembaString())
+		}
+		back, err := c.Cubie()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if back != cc {
+			t.Fatalf("Cubie() = %+v, want %+v", back, cc)
+		}
+	}
+}
+
+func TestCubieKociembaConvention(t *testing.T) {
+	// R as tabulated by Kociemba
+	want := CubieCube{
+		CP:      [8]Corner{DFR, UFL, ULB, URF, DRB, DLF, DBL, UBR},
+		CO:      [8]int8{2, 0, 0, 1, 1, 0, 0, 2},
+		EP:      [12]Edge{FR, UF, UL, UB, BR, DF, DL, DB, DR, FL, BL, UR},
+		Centers: SolvedCubie().Centers,
+	}
+	if got := moveCubies[R]; got != want {
+		t.Fatalf("R = %+v, want %+v", got, want)
+	}
+}
+
+func TestCubieMultiplyAndInverse(t *testing.T) {
+	moves := MustParseMoves("R U Rw' M2 x F E' z S D2")
+	scramble := NewScrambler(11).RandomMoves(15)
+	a, b := SolvedCubie(), SolvedCubie()
+	a.ApplyMoves(moves)
+	b.ApplyMoves(scramble)
+
+	// a b is a's moves followed by b's
+	both := SolvedCubie()
+	both.ApplyMoves(append(append([]Move(nil), moves...), scramble...))
+	ab := a.Multiply(&b)
+	if ab != both {
+		t.Fatalf("a b = %+v, want %+v", ab, both)
+	}
+	bInv := b.Inverse()
+	if back := ab.Multiply(&bInv); back != a {
+		t.Fatalf("(a b) b' = %+v, want a = %+v", back, a)
+	}
+	aInv := a.Inverse()
+	if id := a.Multiply(&aInv); id != SolvedCubie() {
+		t.Fatalf("a a' = %+v, want the identity", id)
+	}
+
+	// The inverse is the inverse move sequence
+	inv := SolvedCubie()
+	inv.ApplyMoves(Invert(moves))
+	if inv != aInv {
+		t.Fatalf("Inverse() = %+v, want %+v", aInv, inv)
+	}
+}
+
+func TestCubieIsSolved(t *testing.T) {
+	if len(rotationCubies) != 24 {
+		t.Fatalf("%d rotations, want 24", len(rotationCubies))
+	}
+	cc := SolvedCubie()
+	cc.ApplyMoves(MustParseMoves("x y' z2"))
+	if !cc.IsSolved() {
+		t.Fatal("rotated solved cubie cube is not solved")
+	}
+	cc.ApplyMove(U)
+	if cc.IsSolved() {
+		t.Fatal("cubie cube with U turned is solved")
+	}
+}
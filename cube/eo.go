@@ -0,0 +1,64 @@
+package cube
+
+// Edge orientation. Relative to an axis, an edge is good if it can be
+// solved without quarter turns of the two faces on that axis, and bad if
+// it can't; only those quarter turns change which edges are bad. Methods
+// like ZZ start by making every edge good on the F/B axis, after which the
+// rest of the solve needs no F or B quarter turns.
+
+// Axis is one of the cube's three axes, named by the faces at its ends
+type Axis int
+
+const (
+	AxisFB Axis = iota // front to back, the usual axis for ZZ
+	AxisRL             // right to left
+	AxisUD             // up to down
+)
+
+func (a Axis) String() string {
+	return [...]string{"F/B", "R/L", "U/D"}[a]
+}
+
+// axisFaces lists the faces at the ends of each axis
+var axisFaces = [3][2]Face{{Front, Back}, {Right, Left}, {Up, Down}}
+
+// BadEdges returns the positions of the edges that are bad relative to
+// axis, with the cube as it is held. Each edge has a reference sticker:
+// the one in the colors of the U and D centers (F and B when the axis is
+// U/D), or if it has neither, the one in the axis's colors. It is good
+// when that sticker is on the face it would be on when solved, of those
+// two pairs.
+func (c *Cube) BadEdges(axis Axis) []Edge {
+	primary, secondary := axisFaces[AxisUD], axisFaces[axis]
+	if axis == AxisUD {
+		primary = axisFaces[AxisFB]
+	}
+	hasColor := func(col Color, faces [2]Face) bool {
+		return col == c.faces[faces[0]][4] || col == c.faces[faces[1]][4]
+	}
+	onFaces := func(s Sticker, faces [2]Face) bool {
+		return s.Face == faces[0] || s.Face == faces[1]
+	}
+
+	var bad []Edge
+	for e := UR; e <= BR; e++ {
+		stickers := e.Stickers()
+		var colors [2]Color
+		for n, s := range stickers {
+			colors[n] = c.faces[s.Face][s.Index]
+		}
+		// The piece's reference color, and the position's reference sticker
+		ref := colors[0]
+		if hasColor(colors[1], primary) || !hasColor(colors[0], primary) && hasColor(colors[1], secondary) {
+			ref = colors[1]
+		}
+		at := 0
+		if onFaces(stickers[1], primary) || !onFaces(stickers[0], primary) && onFaces(stickers[1], secondary) {
+			at = 1
+		}
+		if colors[at] != ref {
+			bad = append(bad, e)
+		}
+	}
+	return bad
+}
@@ -0,0 +1,226 @@
+package cube
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Ergonomics: how quickly a sequence of moves can be performed, rather
+// than how many moves it has. R U R' U' flows from the fingers while
+// B L' B' L needs the cube turned in the hands, though both are four
+// moves. A CostModel prices each turn by its layer, half turns and wrist
+// turns, the regrips a hand needs when its wrist has turned as far as it
+// goes, and awkward or quick transitions between moves. It ranks the
+// algorithm generator's results and breaks ties between equally short
+// solutions (see Options.Cost).
+
+// CostModel scores sequences of moves by how hard they are to perform.
+// Costs are in the time of an easy quarter turn, so R and U cost 1. It
+// reads from JSON with the field names below; see LoadCostModel.
+type CostModel struct {
+	// Layers is the cost of a quarter turn of each layer or rotation, by
+	// base move, e.g. "R", "Rw", "M" or "y"
+	Layers map[string]float64 `json:"layers"`
+	// Default is the cost of a quarter turn of a layer missing from Layers
+	Default float64 `json:"default"`
+	// HalfTurn is the extra cost of a half turn over a quarter turn
+	HalfTurn float64 `json:"half_turn"`
+	// WristTurn is the extra cost of each quarter turn made with a wrist:
+	// those of R and Rw with the right hand, L and Lw with the left
+	WristTurn float64 `json:"wrist_turn"`
+	// WristRange is how many quarter turns from its starting grip a wrist
+	// can turn; a turn that would take it further costs Regrip, as the
+	// hand lets go to start again
+	WristRange int     `json:"wrist_range"`
+	Regrip     float64 `json:"regrip"`
+	// Pairs are extra costs of one move straight after another, by the
+	// two moves, e.g. "R U'"; negative costs make a quick pair cheaper
+	Pairs map[string]float64 `json:"pairs"`
+}
+
+// DefaultCostModel returns the cost model used when none is given: R and
+// U are quickest, with the right hand doing most of the work; moves the
+// hands can't reach without turning the cube, such as B, cost most.
+func DefaultCostModel() *CostModel {
+	return &CostModel{
+		Layers: map[string]float64{
+			"R": 1, "U": 1, "L": 1.2, "F": 1.4, "D": 1.5, "B": 2.2,
+			"Rw": 1.1, "Lw": 1.4, "Uw": 1.6, "Fw": 1.8, "Dw": 1.8, "Bw": 2.5,
+			"M": 1.3, "E": 2, "S": 1.8,
+			"x": 1.5, "y": 1.5, "z": 2,
+		},
+		Default:    2,
+		HalfTurn:   0.5,
+		WristTurn:  0.1,
+		WristRange: 2,
+		Regrip:     2,
+		Pairs: map[string]float64{
+			// Triggers: a finger flick straight after the wrist turns back
+			"R U": -0.1, "R U'": -0.1, "R' U": -0.1, "R' U'": -0.1,
+			"U R": -0.1, "U R'": -0.1, "U' R": -0.1, "U' R'": -0.1,
+			// Turning the opposite face straight away breaks the grip
+			"U D": 0.5, "D U": 0.5, "F B": 0.5, "B F": 0.5, "R L": 0.3, "L R": 0.3,
+		},
+	}
+}
+
+// Cost returns the cost of performing moves in order. Moves that aren't
+// valid notation cost Default.
+func (cm *CostModel) Cost(moves []Move) float64 {
+	total := 0.0
+	var wrist [2]int // quarter turns of the right and left wrists from their grips
+	var prev Move
+	for _, m := range moves {
+		if _, ok := moveTable[m]; !ok {
+			canonical, err := ParseMove(string(m))
+			if err != nil {
+				total += cm.Default
+				prev = ""
+				continue
+			}
+			m = canonical
+		}
+		base, turns := splitMove(m)
+		cost, ok := cm.Layers[base]
+		if !ok {
+			cost = cm.Default
+		}
+		total += cost
+		quarters := 1
+		if turns == 2 {
+			total += cm.HalfTurn
+			quarters = 2
+		}
+		if hand := wristHand(base); hand >= 0 {
+			total += cm.WristTurn * float64(quarters)
+			// A half turn goes whichever way leaves the wrist nearer its
+			// grip
+			d := [4]int{0, 1, 2, -1}[turns]
+			if turns == 2 && wrist[hand] > 0 {
+				d = -2
+			}
+			if w := wrist[hand] + d; w > cm.WristRange || w < -cm.WristRange {
+				total += cm.Regrip
+				wrist[hand] = d
+			} else {
+				wrist[hand] = w
+			}
+		}
+		if prev != "" {
+			total += cm.Pairs[string(prev)+" "+string(m)]
+		}
+		prev = m
+	}
+	return total
+}
+
+// compare orders sequences shortest first and, among those as long,
+// cheapest first
+func (cm *CostModel) compare(a, b []Move) int {
+	return cmp.Or(cmp.Compare(len(a), len(b)), cmp.Compare(cm.Cost(a), cm.Cost(b)))
+}
+
+// shorter reports whether a is shorter than b, or as long and cheaper. A
+// nil model compares lengths alone.
+func (cm *CostModel) shorter(a, b []Move) bool {
+	if cm == nil {
+		return len(a) < len(b)
+	}
+	return cm.compare(a, b) < 0
+}
+
+// wristHand returns which hand turns base with its wrist, 0 for the right
+// and 1 for the left, or -1 if the fingers turn it
+func wristHand(base string) int {
+	switch base {
+	case "R", "Rw":
+		return 0
+	case "L", "Lw":
+		return 1
+	}
+	return -1
+}
+
+// Validate checks that the model names real moves and has no negative
+// costs, apart from Pairs
+func (cm *CostModel) Validate() error {
+	for base, cost := range cm.Layers {
+		if m, err := ParseMove(base); err != nil || m != Move(base) {
+			return fmt.Errorf("cost model: unknown layer %q", base)
+		}
+		if cost < 0 {
+			return fmt.Errorf("cost model: %s costs %g", base, cost)
+		}
+	}
+	for pair := range cm.Pairs {
+		moves, err := ParseMoves(pair)
+		if err != nil || len(moves) != 2 || FormatMoves(moves) != pair {
+			return fmt.Errorf("cost model: %q is not a pair of moves such as \"R U'\"", pair)
+		}
+	}
+	switch {
+	case cm.Default < 0, cm.HalfTurn < 0, cm.WristTurn < 0, cm.Regrip < 0:
+		return errors.New("cost model: costs can't be negative")
+	case cm.WristRange < 1:
+		return fmt.Errorf("cost model: wrist range %d, want at least 1", cm.WristRange)
+	}
+	return nil
+}
+
+// CostModelPath is the cost model file LoadCostModel reads when given no
+// path, under os.UserConfigDir, or "" if there is no config directory
+func CostModelPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "rubiks-cube-solver", "ergonomics.json")
+}
+
+// LoadCostModel reads a cost model from a JSON file, e.g.
+//
+//	{"layers": {"F": 1.2, "B": 3}, "regrip": 2.5, "pairs": {"R' F": 0.4}}
+//
+// Fields it leaves out, and layers and pairs it doesn't mention, keep
+// their DefaultCostModel values. An empty path reads CostModelPath, and if
+// that file doesn't exist the default model is returned as it is.
+func LoadCostModel(path string) (*CostModel, error) {
+	cm := DefaultCostModel()
+	optional := path == ""
+	if optional {
+		if path = CostModelPath(); path == "" {
+			return cm, nil
+		}
+	}
+	data, err := os.ReadFile(path)
+	if optional && errors.Is(err, fs.ErrNotExist) {
+		return cm, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(cm); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	// Pairs may be written loosely, e.g. "R  U'"
+	pairs := map[string]float64{}
+	for pair, cost := range cm.Pairs {
+		if moves, err := ParseMoves(pair); err == nil && len(moves) == 2 {
+			pair = FormatMoves(moves)
+		}
+		pairs[pair] = cost
+	}
+	cm.Pairs = pairs
+	if err := cm.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return cm, nil
+}
@@ -0,0 +1,157 @@
+package cube
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCostModel(t *testing.T) {
+	cm := DefaultCostModel()
+	if err := cm.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		quicker, slower string
+	}{
+		{"R U R' U'", "B L' B' L"},  // preferred faces
+		{"R U R' U'", "R' F R F'"},  // triggers
+		{"R U R' U R", "R U R U R"}, // the wrist turns too far and regrips
+		{"R U2 R'", "R U U R'"},     // a half turn is less than two quarters
+		{"U R2 D", "U D R2"},        // opposite faces in a row
+	}
+	for _, tt := range tests {
+		q, s := cm.Cost(MustParseMoves(tt.quicker)), cm.Cost(MustParseMoves(tt.slower))
+		if q >= s {
+			t.Errorf("%s costs %.2f, no less than %s at %.2f", tt.quicker, q, tt.slower, s)
+		}
+	}
+	if got := cm.Cost(MustParseMoves("R U")); got != 2 {
+		t.Errorf("R U costs %g, want 2", got)
+	}
+	// Other spellings cost as their canonical form
+	if got, want := cm.Cost([]Move{"r", "U"}), cm.Cost(MustParseMoves("Rw U")); got != want {
+		t.Errorf("r U costs %g, Rw U %g", got, want)
+	}
+	if got := cm.Cost([]Move{"Q"}); got != cm.Default {
+		t.Errorf("Q costs %g, want the default %g", got, cm.Default)
+	}
+	if got := cm.Cost(nil); got != 0 {
+		t.Errorf("no moves cost %g", got)
+	}
+}
+
+func TestCostModelRegrip(t *testing.T) {
+	cm := &CostModel{Default: 1, WristRange: 2, Regrip: 10}
+	tests := []struct {
+		moves string
+		want  float64
+	}{
+		{"R R", 2},
+		{"R U R U R", 15}, // three quarter turns one way
+		{"R2 U R2", 3},    // the second half turn goes back
+		{"R U R' U R U R", 7},
+		{"R L R L R", 15},
+	}
+	for _, tt := range tests {
+		if got := cm.Cost(MustParseMoves(tt.moves)); got != tt.want {
+			t.Errorf("%s: cost %g, want %g", tt.moves, got, tt.want)
+		}
+	}
+}
+
+func TestLoadCostModel(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "costs.json")
+	os.WriteFile(path, []byte(`{"layers": {"B": 1}, "regrip": 5, "pairs": {"R'  F": 0.4}}`), 0o644)
+	cm, err := LoadCostModel(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	def := DefaultCostModel()
+	if cm.Layers["B"] != 1 || cm.Regrip != 5 || cm.Pairs["R' F"] != 0.4 {
+		t.Errorf("settings not read: %+v", cm)
+	}
+	if cm.Layers["R"] != def.Layers["R"] || cm.HalfTurn != def.HalfTurn || cm.Pairs["R U"] != def.Pairs["R U"] {
+		t.Errorf("defaults not kept: %+v", cm)
+	}
+
+	for _, bad := range []string{
+		`{"layer": {}}`,
+		`{"layers": {"Q": 1}}`,
+		`{"layers": {"R": -1}}`,
+		`{"pairs": {"R U F": 1}}`,
+		`{"wrist_range": 0}`,
+		`not json`,
+	} {
+		os.WriteFile(path, []byte(bad), 0o644)
+		if _, err := LoadCostModel(path); err == nil || !strings.Contains(err.Error(), path) {
+			t.Errorf("%s: got %v, want an error naming the file", bad, err)
+		}
+	}
+	if _, err := LoadCostModel(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("missing file: expected an error")
+	}
+
+	// Without a config file the default model is used
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+	if cm, err := LoadCostModel(""); err != nil || cm.Layers["B"] != def.Layers["B"] {
+		t.Errorf("got %v, %v, want the default model", cm, err)
+	}
+}
+
+func TestRestrictedSolverCost(t *testing.T) {
+	// (R2 U2)3 and (U2 R2)3 are the same, so both solve it; the cost model
+	// picks the one with fewer slow pairs
+	c := NewCube()
+	c.ApplyMoves(MustParseMoves("(R2 U2)3"))
+	set, _ := ParseMoveSet("<R,U>")
+	for slow, want := range map[string]string{"U2 R2": "R2 U2 R2 U2 R2 U2", "R2 U2": "U2 R2 U2 R2 U2 R2"} {
+		cost := &CostModel{Default: 1, WristRange: 2, Pairs: map[string]float64{slow: 1}}
+		s, err := NewSolver("kociemba", Options{Moves: set, Cost: cost})
+		if err != nil {
+			t.Fatal(err)
+		}
+		solution, err := s.Solve(context.Background(), c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := FormatMoves(solution.Moves); got != want {
+			t.Errorf("with %s slow: got %s, want %s", slow, got, want)
+		}
+	}
+}
+
+func TestSolveCFOPCost(t *testing.T) {
+	// Ties are broken by cost, so F2L is as short and no slower
+	cm := DefaultCostModel()
+	s := NewScrambler(1)
+	for i := 0; i < 5; i++ {
+		c := NewCube()
+		c.ApplyMoves(s.RandomMoves(25))
+		_, plain, err := solveCFOP(context.Background(), c, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		solution, quick, err := solveCFOP(context.Background(), c, cm)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f2l := func(stages []Stage) (moves []Move) {
+			for _, stage := range stages[2:6] {
+				moves = append(moves, stage.Moves...)
+			}
+			return moves
+		}
+		if a, b := f2l(plain), f2l(quick); len(b) > len(a) || len(b) == len(a) && cm.Cost(b) > cm.Cost(a) {
+			t.Errorf("F2L %s (%.1f), without costs %s (%.1f)", FormatMoves(b), cm.Cost(b), FormatMoves(a), cm.Cost(a))
+		}
+		c.ApplyMoves(solution)
+		if !c.IsSolved() {
+			t.Errorf("solution %s left %s", FormatMoves(solution), c.KociembaString())
+		}
+	}
+}
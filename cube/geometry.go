@@ -0,0 +1,228 @@
+package cube
+
+import "slices"
+
+// Sticker geometry. Every sticker has a position in cube coordinates, with
+// x pointing right, y up and z towards the viewer (each -1, 0 or 1), and
+// the outward normal of its face. Whole-cube rotations are 3x3 integer
+// matrices acting on those vectors.
+
+type vec3 [3]int
+
+// rotation is a proper rotation matrix taking a cube position to where it
+// ends up when the whole cube is turned
+type rotation [3][3]int
+
+var (
+	identityRotation = rotation{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+	// rotationX turns the cube like R: (x, y, z) -> (x, z, -y)
+	rotationX = rotation{{1, 0, 0}, {0, 0, 1}, {0, -1, 0}}
+	// rotationY turns the cube like U: (x, y, z) -> (-z, y, x)
+	rotationY = rotation{{0, 0, -1}, {0, 1, 0}, {1, 0, 0}}
+	// rotationZ turns the cube like F: (x, y, z) -> (y, -x, z)
+	rotationZ = rotation{{0, 1, 0}, {-1, 0, 0}, {0, 0, 1}}
+)
+
+func (r rotation) apply(v vec3) vec3 {
+	var out vec3
+	for i := 0; i < 3; i++ {
+		out[i] = r[i][0]*v[0] + r[i][1]*v[1] + r[i][2]*v[2]
+	}
+	return out
+}
+
+// then returns the rotation that performs r followed by s
+func (r rotation) then(s rotation) rotation {
+	var out rotation
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			for k := 0; k < 3; k++ {
+				out[i][j] += s[i][k] * r[k][j]
+			}
+		}
+	}
+	return out
+}
+
+// inverse of a rotation matrix is its transpose
+func (r rotation) inverse() rotation {
+	var out rotation
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			out[i][j] = r[j][i]
+		}
+	}
+	return out
+}
+
+// times returns r applied n times
+func (r rotation) times(n int) rotation {
+	out := identityRotation
+	for i := 0; i < n; i++ {
+		out = out.then(r)
+	}
+	return out
+}
+
+// faceNormals holds the outward normal of each face, indexed by Face
+var faceNormals = [6]vec3{
+	Front: {0, 0, 1},
+	Right: {1, 0, 0},
+	Back:  {0, 0, -1},
+	Left:  {-1, 0, 0},
+	Up:    {0, 1, 0},
+	Down:  {0, -1, 0},
+}
+
+// faceWithNormal returns the face whose outward normal is n
+func faceWithNormal(n vec3) Face {
+	for f, normal := range faceNormals {
+		if normal == n {
+			return Face(f)
+		}
+	}
+	panic("cube: not a face normal")
+}
+
+// stickerPos returns the position of sticker i on face f
+func stickerPos(f Face, i int) vec3 {
+	row, col := i/3, i%3
+	switch f {
+	case Up:
+		return vec3{col - 1, 1, row - 1}
+	case Down:
+		return vec3{col - 1, -1, 1 - row}
+	case Front:
+		return vec3{col - 1, 1 - row, 1}
+	case Back:
+		return vec3{1 - col, 1 - row, -1}
+	case Right:
+		return vec3{1, 1 - row, 1 - col}
+	default: // Left
+		return vec3{-1, 1 - row, col - 1}
+	}
+}
+
+// Position returns where the sticker sits, as the center of its cubie in
+// the coordinates above, and the outward normal of its face
+func (s Sticker) Position() (pos, normal [3]int) {
+	return stickerPos(s.Face, s.Index), faceNormals[s.Face]
+}
+
+// stickerAt returns the face and index of the sticker at pos on the face
+// with normal n
+func stickerAt(pos, n vec3) (Face, int) {
+	f := faceWithNormal(n)
+	for i := 0; i < 9; i++ {
+		if stickerPos(f, i) == pos {
+			return f, i
+		}
+	}
+	panic("cube: no sticker at position")
+}
+
+// stickerPerm maps every sticker, numbered face*9 + index, to the sticker
+// whose color moves there
+type stickerPerm [54]int8
+
+// permFor returns the permutation made by turning the stickers whose
+// positions satisfy inLayer by r
+func permFor(r rotation, inLayer func(pos vec3) bool) *stickerPerm {
+	var p stickerPerm
+	for k := range p {
+		p[k] = int8(k)
+	}
+	for f := Face(0); f < 6; f++ {
+		n := r.apply(faceNormals[f])
+		for i := 0; i < 9; i++ {
+			pos := stickerPos(f, i)
+			if !inLayer(pos) {
+				continue
+			}
+			nf, ni := stickerAt(r.apply(pos), n)
+			p[int(nf)*9+ni] = int8(int(f)*9 + i)
+		}
+	}
+	return &p
+}
+
+// permute moves the stickers of c by p
+func (c *Cube) permute(p *stickerPerm) {
+	old := c.faces
+	for k, from := range p {
+		c.faces[k/9][k%9] = old[from/9][from%9]
+	}
+}
+
+// held returns the cube as seen after turning the whole cube by r. The
+// receiver is unchanged.
+func (c *Cube) held(r rotation) *Cube {
+	out := c.Clone()
+	out.permute(permFor(r, func(vec3) bool { return true }))
+	return out
+}
+
+// rotations lists the 24 ways of holding the cube
+var rotations = func() []rotation {
+	out := []rotation{identityRotation}
+	for i := 0; i < len(out); i++ {
+		for _, r := range []rotation{rotationX, rotationY} {
+			if next := out[i].then(r); !slices.Contains(out, next) {
+				out = append(out, next)
+			}
+		}
+	}
+	return out
+}()
+
+// holding returns a rotation r for which c.held(r) satisfies ok
+func (c *Cube) holding(ok func(h *Cube) bool) (rotation, bool) {
+	for _, r := range rotations {
+		if ok(c.held(r)) {
+			return r, true
+		}
+	}
+	return identityRotation, false
+}
+
+// unheld converts a move made while holding the cube turned by r into the
+// same physical move in the cube's own orientation. Slice moves and
+// rotations have no counterpart on some faces (there is no slice turning
+// like R), so those become the opposite move turned the other way: M'
+// rather than "M like R".
+func (r rotation) unheld(m Move) Move {
+	base, turns := splitMove(m)
+	ml := moveLayers[base]
+	n := r.inverse().apply(faceNormals[ml.face])
+	opposite := vec3{-n[0], -n[1], -n[2]}
+	suffix := [...]string{"", "", "2", "'"}
+	for b, other := range moveLayers {
+		if faceNormals[other.face] == n && slices.Equal(other.layers, ml.layers) {
+			return Move(b + suffix[turns])
+		}
+	}
+	for b, other := range moveLayers {
+		if faceNormals[other.face] == opposite && slices.Equal(other.layers, ml.layers) {
+			return Move(b + suffix[4-turns])
+		}
+	}
+	panic("cube: no move " + string(m) + " when unheld")
+}
+
+// moveFace returns the face turned by a face move such as "R'"
+func moveFace(m Move) Face {
+	switch m[0] {
+	case 'F':
+		return Front
+	case 'R':
+		return Right
+	case 'B':
+		return Back
+	case 'L':
+		return Left
+	case 'U':
+		return Up
+	default:
+		return Down
+	}
+}
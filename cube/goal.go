@@ -0,0 +1,283 @@
+package cube
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Partial goals: solving only some of the cube, for trainers and for
+// algorithms that leave the rest free. A Goal names the pieces that must
+// be solved, which means every sticker of the piece matches the center
+// next to it, and single sticker places that must match their face's
+// center, e.g. the top face's edge stickers for an oriented last layer
+// cross whose edges may still be swapped. SolveGoal finds the fewest moves
+// that reach one.
+
+// Goal is part of the cube that must be solved; everything else doesn't
+// matter. The zero Goal is reached by any cube.
+type Goal struct {
+	Corners []Corner
+	Edges   []Edge
+	// Stickers are places that must show their face's color, as the cube
+	// is held
+	Stickers []Sticker
+	// AUF accepts the goal up to a final turn of U
+	AUF bool
+}
+
+// String spells a sticker the way ParseGoal reads it: its face and its
+// number on that face, 1 to 9 in reading order, e.g. "U5" for the center
+func (s Sticker) String() string {
+	return fmt.Sprintf("%s%d", s.Face, s.Index+1)
+}
+
+// facelet returns the sticker's index in the Kociemba string
+func (s Sticker) facelet() int {
+	return 9*slices.Index(kociembaFaceOrder[:], s.Face) + s.Index
+}
+
+// Reached reports whether c, as it is held, meets the goal
+func (g Goal) Reached(c *Cube) bool {
+	v := c.Clone()
+	for turn := 0; turn < 4; turn++ {
+		if v.piecesSolved(g.Corners, g.Edges) && !slices.ContainsFunc(g.Stickers, func(s Sticker) bool {
+			return !v.faceletSolved(s.facelet())
+		}) {
+			return true
+		}
+		if !g.AUF {
+			break
+		}
+		v.ApplyMove(U)
+	}
+	return false
+}
+
+// Covers reports whether the goal names sticker place s, on its own or as
+// part of a piece
+func (g Goal) Covers(s Sticker) bool {
+	for _, c := range g.Corners {
+		if stickers := c.Stickers(); slices.Contains(stickers[:], s) {
+			return true
+		}
+	}
+	for _, e := range g.Edges {
+		if stickers := e.Stickers(); slices.Contains(stickers[:], s) {
+			return true
+		}
+	}
+	return slices.Contains(g.Stickers, s)
+}
+
+// String writes the goal the way ParseGoal reads it
+func (g Goal) String() string {
+	var names []string
+	for _, c := range g.Corners {
+		names = append(names, c.String())
+	}
+	for _, e := range g.Edges {
+		names = append(names, e.String())
+	}
+	for _, s := range g.Stickers {
+		names = append(names, s.String())
+	}
+	if g.AUF {
+		names = append(names, "AUF")
+	}
+	return strings.Join(names, ",")
+}
+
+// subset returns the goal for the restricted search, with the stickers of
+// a place whose stickers are all named turned into its piece
+func (g Goal) subset() subsetGoal {
+	sg := subsetGoal{auf: g.AUF}
+	for _, c := range g.Corners {
+		sg.corners |= 1 << c
+	}
+	for _, e := range g.Edges {
+		sg.edges |= 1 << e
+	}
+	for _, s := range g.Stickers {
+		if s.Index != 4 {
+			sg.stickers |= 1 << s.facelet()
+		}
+	}
+	for i, f := range cornerFacelet {
+		if mask := uint64(1)<<f[0] | 1<<f[1] | 1<<f[2]; sg.stickers&mask == mask {
+			sg.corners |= 1 << i
+		}
+	}
+	for i, f := range edgeFacelet {
+		if mask := uint64(1)<<f[0] | 1<<f[1]; sg.stickers&mask == mask {
+			sg.edges |= 1 << i
+		}
+	}
+	for i, f := range cornerFacelet {
+		if sg.corners&(1<<i) != 0 {
+			sg.stickers &^= 1<<f[0] | 1<<f[1] | 1<<f[2]
+		}
+	}
+	for i, f := range edgeFacelet {
+		if sg.edges&(1<<i) != 0 {
+			sg.stickers &^= 1<<f[0] | 1<<f[1]
+		}
+	}
+	return sg
+}
+
+// pieceGroups are the named groups of pieces ParseGoal accepts
+var pieceGroups = map[string]struct {
+	corners []Corner
+	edges   []Edge
+}{
+	"all":     {[]Corner{URF, UFL, ULB, UBR, DFR, DLF, DBL, DRB}, []Edge{UR, UF, UL, UB, DR, DF, DL, DB, FR, FL, BL, BR}},
+	"corners": {[]Corner{URF, UFL, ULB, UBR, DFR, DLF, DBL, DRB}, nil},
+	"edges":   {nil, []Edge{UR, UF, UL, UB, DR, DF, DL, DB, FR, FL, BL, BR}},
+	"cross":   {nil, []Edge{DR, DF, DL, DB}},
+	"line":    {nil, []Edge{DF, DB}},
+	"f2l":     {[]Corner{DFR, DLF, DBL, DRB}, []Edge{DR, DF, DL, DB, FR, FL, BL, BR}},
+	"ll":      {[]Corner{URF, UFL, ULB, UBR}, []Edge{UR, UF, UL, UB}},
+	// Roux's 1x2x3 blocks on the left and right
+	"first-block":  {[]Corner{DLF, DBL}, []Edge{DL, FL, BL}},
+	"second-block": {[]Corner{DFR, DRB}, []Edge{DR, FR, BR}},
+}
+
+// stickerGroups are the named groups of sticker indices on a face that
+// ParseGoal accepts after a face letter, e.g. "U-cross"
+var stickerGroups = map[string][]int{
+	"face":    {0, 1, 2, 3, 5, 6, 7, 8},
+	"cross":   {1, 3, 5, 7},
+	"corners": {0, 2, 6, 8},
+}
+
+// ParseGoal parses a comma separated list of what must be solved, e.g.
+// "F2L,URF,UF" or "U-cross": pieces by their positions' names or as
+// groups (all, corners, edges, cross and line on D, F2L, LL, and Roux's
+// first-block and second-block), stickers by face and number (U1 to U9 in
+// reading order), or a face's stickers as U-face, U-cross or U-corners.
+// "AUF" accepts the goal up to a final turn of U.
+func ParseGoal(s string) (Goal, error) {
+	var g Goal
+	addCorner := func(c Corner) {
+		if !slices.Contains(g.Corners, c) {
+			g.Corners = append(g.Corners, c)
+		}
+	}
+	addEdge := func(e Edge) {
+		if !slices.Contains(g.Edges, e) {
+			g.Edges = append(g.Edges, e)
+		}
+	}
+	addSticker := func(st Sticker) {
+		if !slices.Contains(g.Stickers, st) {
+			g.Stickers = append(g.Stickers, st)
+		}
+	}
+	for _, name := range strings.Split(s, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if strings.EqualFold(name, "auf") {
+			g.AUF = true
+			continue
+		}
+		if group, ok := pieceGroups[strings.ToLower(name)]; ok {
+			for _, c := range group.corners {
+				addCorner(c)
+			}
+			for _, e := range group.edges {
+				addEdge(e)
+			}
+			continue
+		}
+		if face, group, ok := strings.Cut(name, "-"); ok {
+			f, ok := faceNamed(face)
+			indices, known := stickerGroups[strings.ToLower(group)]
+			if !ok || !known {
+				return Goal{}, fmt.Errorf("goal: unknown stickers %q", name)
+			}
+			for _, i := range indices {
+				addSticker(Sticker{f, i})
+			}
+			continue
+		}
+		if len(name) == 2 && name[1] >= '1' && name[1] <= '9' {
+			f, ok := faceNamed(name[:1])
+			if !ok {
+				return Goal{}, fmt.Errorf("goal: unknown sticker %q", name)
+			}
+			addSticker(Sticker{f, int(name[1] - '1')})
+			continue
+		}
+		c, e, ok := pieceNamed(strings.ToUpper(name))
+		switch {
+		case !ok:
+			return Goal{}, fmt.Errorf("goal: unknown piece %q", name)
+		case c >= 0:
+			addCorner(c)
+		default:
+			addEdge(e)
+		}
+	}
+	if g.Corners == nil && g.Edges == nil && g.Stickers == nil {
+		return Goal{}, fmt.Errorf("goal: nothing to solve in %q", s)
+	}
+	return g, nil
+}
+
+// faceNamed returns the face with the letter name, e.g. "U"
+func faceNamed(name string) (Face, bool) {
+	for f := Front; f <= Down; f++ {
+		if strings.EqualFold(f.String(), name) {
+			return f, true
+		}
+	}
+	return 0, false
+}
+
+// pieceNamed returns the corner or edge position called name, with -1 for
+// the other
+func pieceNamed(name string) (Corner, Edge, bool) {
+	for c := URF; c <= DRB; c++ {
+		if c.String() == name {
+			return c, -1, true
+		}
+	}
+	for e := UR; e <= BR; e++ {
+		if e.String() == name {
+			return -1, e, true
+		}
+	}
+	return -1, -1, false
+}
+
+// SolveGoal returns up to n of the shortest move sequences, at most
+// maxDepth long, that take c to goal, shortest first; a negative n asks
+// for every one. If moves is nil they may use the 18 face turns, otherwise
+// only moves, e.g. from ParseMoveSet. It returns ErrUnreachable if the
+// moves can't reach the goal: for the whole cube always, for part of it
+// when they can't put one of its groups of pieces in place. Other goals
+// out of reach find no solution within maxDepth.
+func SolveGoal(ctx context.Context, c *Cube, goal Goal, moves []Move, maxDepth, n int) ([][]Move, error) {
+	if moves == nil {
+		moves = faceTurns
+	}
+	solutions, err := solveSubset(ctx, c, subsetQuery{moves: moves, goal: goal.subset(), maxDepth: maxDepth, n: n})
+	if err != nil {
+		return nil, err
+	}
+	for _, solution := range solutions {
+		check := c.Clone()
+		check.ApplyMoves(solution)
+		if !goal.Reached(check) {
+			return nil, fmt.Errorf("goal: %s does not reach %s", FormatMoves(solution), goal)
+		}
+	}
+	return solutions, nil
+}
+
+// faceTurns are the 18 turns of the outer faces
+var faceTurns = MustParseMoves("U U2 U' R R2 R' F F2 F' D D2 D' L L2 L' B B2 B'")
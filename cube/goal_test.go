@@ -0,0 +1,152 @@
+package cube
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestParseGoal(t *testing.T) {
+	tests := []struct {
+		in                       string
+		corners, edges, stickers int
+		auf                      bool
+	}{
+		{"all", 8, 12, 0, false},
+		{"F2L", 4, 8, 0, false},
+		{"cross,DFR,FR", 1, 5, 0, false},
+		{"ll, URF", 4, 4, 0, false},
+		{"first-block,second-block", 4, 6, 0, false},
+		{"uf", 0, 1, 0, false},
+		{"U-cross", 0, 0, 4, false},
+		{"f2l,U-face,AUF", 4, 8, 8, true},
+		{"U2,u4,F1", 0, 0, 3, false},
+		{"D-corners,D-cross", 0, 0, 8, false},
+	}
+	for _, tt := range tests {
+		g, err := ParseGoal(tt.in)
+		if err != nil {
+			t.Errorf("%q: %v", tt.in, err)
+			continue
+		}
+		if len(g.Corners) != tt.corners || len(g.Edges) != tt.edges || len(g.Stickers) != tt.stickers || g.AUF != tt.auf {
+			t.Errorf("%q: got %s", tt.in, g)
+		}
+		if again, err := ParseGoal(g.String()); err != nil || again.String() != g.String() {
+			t.Errorf("%q: %s reads back as %s, %v", tt.in, g, again, err)
+		}
+	}
+	for _, bad := range []string{"", ",", "AUF", "UFX", "top", "U0", "X1", "U-edges", "Q-face"} {
+		if _, err := ParseGoal(bad); err == nil {
+			t.Errorf("%q: expected an error", bad)
+		}
+	}
+}
+
+func TestGoalReached(t *testing.T) {
+	tests := []struct {
+		goal, moves string
+		want        bool
+	}{
+		{"all", "", true},
+		{"cross", "R U R'", true},
+		{"cross", "R", false},
+		{"U-cross", "U", true},
+		{"U-cross", "F", false},
+		{"U-face", "R U R' U R U2 R'", false},
+		// A T-perm leaves the top face's stickers, not its pieces
+		{"U-face", "R U R' U' R' F R2 U' R' U' R U R' F'", true},
+		{"ll", "R U R' U' R' F R2 U' R' U' R U R' F'", false},
+		{"ll", "U", false},
+		{"ll,AUF", "U", true},
+		{"f2l", "M2 U M2 U2 M2 U M2", true},
+		{"f2l", "x", true},
+	}
+	for _, tt := range tests {
+		g, err := ParseGoal(tt.goal)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c := NewCube()
+		c.ApplyMoves(MustParseMoves(tt.moves))
+		if got := g.Reached(c); got != tt.want {
+			t.Errorf("%s after %q: got %t, want %t", tt.goal, tt.moves, got, tt.want)
+		}
+	}
+	if !(Goal{}).Reached(NewCube()) {
+		t.Error("the zero goal isn't reached")
+	}
+}
+
+func TestGoalSubset(t *testing.T) {
+	// A place whose stickers are all named is its piece
+	g := Goal{Stickers: []Sticker{{Up, 8}, {Right, 0}, {Front, 2}, {Up, 7}, {Up, 4}}}
+	sg := g.subset()
+	if sg.corners != 1<<URF || sg.edges != 0 || sg.stickers != 1<<(Sticker{Up, 7}).facelet() {
+		t.Fatalf("got %+v", sg)
+	}
+}
+
+func TestSolveGoal(t *testing.T) {
+	tests := []struct {
+		goal, moves, scramble string
+		length                int
+	}{
+		{"cross", "", "R U F' L2 D B R' U2 F D' L", 7},
+		{"cross,DFR,FR", "", "R U R'", 3},
+		{"first-block", "", "R U F' L2 D B R' U2 F D' L", 7},
+		{"U-cross", "", "F R U R' U' F'", 4},
+		{"U-face", "<R,U,F>", "R U R' U R U2 R' F R U R' U' F'", 6},
+		{"f2l,U-face,AUF", "<R,U>", "R U R' U R U2 R'", 7},
+		// The centers turn, and the stickers must match them
+		{"U-cross", "<M,U>", "M U M' U2 M U M'", 7},
+	}
+	for _, tt := range tests {
+		goal, _ := ParseGoal(tt.goal)
+		var set []Move
+		if tt.moves != "" {
+			set, _ = ParseMoveSet(tt.moves)
+		}
+		c := NewCube()
+		c.ApplyMoves(MustParseMoves(tt.scramble))
+		solutions, err := SolveGoal(context.Background(), c, goal, set, 12, 1)
+		if err != nil {
+			t.Fatalf("%s %s: %v", tt.goal, tt.scramble, err)
+		}
+		solution := solutions[0]
+		if len(solution) != tt.length {
+			t.Errorf("%s %s: %s, want %d moves", tt.goal, tt.scramble, FormatMoves(solution), tt.length)
+		}
+		c.ApplyMoves(solution)
+		if !goal.Reached(c) {
+			t.Errorf("%s %s: %s left %s", tt.goal, tt.scramble, FormatMoves(solution), c.KociembaString())
+		}
+	}
+}
+
+func TestSolveGoalUnreachable(t *testing.T) {
+	c := NewCube()
+	c.ApplyMove(F)
+	set, _ := ParseMoveSet("<R,U>")
+	goal, _ := ParseGoal("UF")
+	if _, err := SolveGoal(context.Background(), c, goal, set, 12, 1); !errors.Is(err, ErrUnreachable) {
+		t.Fatalf("got %v, want ErrUnreachable", err)
+	}
+}
+
+func TestGoalCovers(t *testing.T) {
+	goal, err := ParseGoal("DFR,UF,U-corners")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []Sticker{{Down, 2}, {Front, 8}, {Right, 6}, {Up, 7}, {Front, 1}, {Up, 0}} {
+		if !goal.Covers(s) {
+			t.Errorf("%s doesn't cover %s", goal, s)
+		}
+	}
+	for _, s := range []Sticker{{Up, 1}, {Front, 7}, {Up, 4}, {Right, 8}} {
+		if goal.Covers(s) {
+			t.Errorf("%s covers %s", goal, s)
+		}
+	}
+}
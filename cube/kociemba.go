@@ -0,0 +1,320 @@
+package cube
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Kociemba's two-phase algorithm.
+//
+// Phase 1 brings the cube into the subgroup G1 = <U, D, R2, L2, F2, B2>, in
+// which every corner and edge is oriented and the four E-slice edges sit in
+// the E slice. Phase 2 then solves the cube using only G1's moves. Both
+// phases are IDA* searches over coordinate move tables, guided by pruning
+// tables giving a lower bound on the moves left (see kociemba_tables.go).
+//
+// The search does not stop at the first solution: it keeps looking for
+// shorter ones until a solution of kociembaTargetLength moves or fewer turns
+// up or the time budget runs out.
+
+const (
+	// kociembaTargetLength is the solution length the search is happy with
+	kociembaTargetLength = 21
+
+	// kociembaTimeout bounds how long the search spends improving on the
+	// first solution it found
+	kociembaTimeout = 2 * time.Second
+
+	// kociembaMaxLength is large enough that phase 2 can always finish
+	// after the shortest phase 1 solution, so some solution is always found
+	kociembaMaxLength = 31
+)
+
+// The 18 face turns are indexed face*3 + power-1, with faces in URFDLB order
+const nMoves = 18
+
+var moveNames = [nMoves]string{
+	"U", "U2", "U'", "R", "R2", "R'", "F", "F2", "F'",
+	"D", "D2", "D'", "L", "L2", "L'", "B", "B2", "B'",
+}
+
+// phase2Moves are the moves of G1
+var phase2Moves = []int{0, 1, 2, 4, 7, 9, 10, 11, 13, 16}
+
+// moveCubes holds the cubie cube of each of the 18 face turns
+var moveCubes = func() [nMoves]CubieCube {
+	var moves [nMoves]CubieCube
+	for i, name := range moveNames {
+		moves[i] = moveCubies[Move(name)]
+	}
+	return moves
+}()
+
+// SolveKociemba solves the cube with Kociemba's two-phase algorithm
+// Returns a near-optimal solution (typically ≤21 moves). The lookup tables
+// are generated on first use and cached on disk, see TableCacheDir.
+func SolveKociemba(c *Cube) ([]Move, error) {
+	return solveKociemba(context.Background(), c)
+}
+
+// kociembaSolver is the registry adapter for SolveKociemba
+type kociembaSolver struct {
+	restrict *restriction
+}
+
+func init() {
+	Register("kociemba", func(opts Options) Solver {
+		return kociembaSolver{restrict: restrictionOf(opts)}
+	})
+}
+
+func (kociembaSolver) Name() string { return "kociemba" }
+
+func (kociembaSolver) Capabilities() Capabilities { return Capabilities{MoveSets: true} }
+
+func (s kociembaSolver) Solve(ctx context.Context, c *Cube) (Solution, error) {
+	if s.restrict != nil {
+		return s.restrict.solve(ctx, c, s.Name())
+	}
+	moves, err := solveKociemba(ctx, c)
+	if err != nil {
+		return Solution{}, err
+	}
+	return Solution{Solver: s.Name(), Moves: moves}, nil
+}
+
+// solveKociemba runs the two-phase search until it finds a short enough
+// solution, its time budget runs out or ctx is done
+func solveKociemba(ctx context.Context, c *Cube) ([]Move, error) {
+	if err := c.Validate(); err != nil {
+		return nil, fmt.Errorf("kociemba: %w", err)
+	}
+	// Reading faces by their centers makes the color scheme and the way
+	// the cube is held irrelevant: the solution turns the faces as they are
+	cc, err := cubieFromFacelets(c.KociembaString())
+	if err != nil {
+		return nil, fmt.Errorf("kociemba: %v", err)
+	}
+
+	deadline := time.Now().Add(kociembaTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	s := &kociembaSearch{
+		ctx:       ctx,
+		tables:    loadKociembaTables(),
+		cc:        cc,
+		maxLength: kociembaMaxLength,
+		deadline:  deadline,
+	}
+	s.run()
+	if s.best == nil {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("kociemba: no solution found")
+	}
+
+	names := make([]string, len(s.best))
+	for i, m := range s.best {
+		names[i] = moveNames[m]
+	}
+	return MustParseMoves(strings.Join(names, " ")), nil
+}
+
+// twist is the corner orientation coordinate (0-2186)
+func (cc *CubieCube) twist() int {
+	twist := 0
+	for i := URF; i < DRB; i++ {
+		twist = 3*twist + int(cc.CO[i])
+	}
+	return twist
+}
+
+// flip is the edge orientation coordinate (0-2047)
+func (cc *CubieCube) flip() int {
+	flip := 0
+	for i := UR; i < BR; i++ {
+		flip = 2*flip + int(cc.EO[i])
+	}
+	return flip
+}
+
+// sliceSorted encodes which positions the E-slice edges (FR, FL, BL, BR)
+// occupy and in what order (0-11879). It is below 24 exactly when all four
+// are in the E slice, in which case it is their permutation.
+func (cc *CubieCube) sliceSorted() int {
+	a, x := 0, 0
+	var edge4 [4]Edge
+	for j := BR; j >= UR; j-- {
+		if cc.EP[j] >= FR {
+			a += binomial(int(BR-j), x+1)
+			edge4[3-x] = cc.EP[j]
+			x++
+		}
+	}
+	b := 0
+	for j := 3; j > 0; j-- {
+		k := 0
+		for edge4[j] != FR+Edge(j) {
+			// rotate edge4[0..j] left by one
+			first := edge4[0]
+			copy(edge4[:j], edge4[1:j+1])
+			edge4[j] = first
+			k++
+		}
+		b = (j+1)*b + k
+	}
+	return 24*a + b
+}
+
+// cornerPerm is the corner permutation coordinate (0-40319)
+func (cc *CubieCube) cornerPerm() int {
+	return permRank(cc.CP[:])
+}
+
+// udEdgePerm is the permutation of the eight U and D layer edges
+// (0-40319). It is only meaningful in G1.
+func (cc *CubieCube) udEdgePerm() int {
+	return permRank(cc.EP[:8])
+}
+
+func binomial(n, k int) int {
+	if k < 0 || k > n {
+		return 0
+	}
+	result := 1
+	for i := 0; i < k; i++ {
+		result = result * (n - i) / (i + 1)
+	}
+	return result
+}
+
+// kociembaSearch holds the state of one two-phase search
+type kociembaSearch struct {
+	ctx       context.Context
+	tables    *kociembaTables
+	cc        CubieCube
+	path      [kociembaMaxLength]int
+	best      []int
+	maxLength int
+	deadline  time.Time
+	nodes     int
+	stopped   bool
+}
+
+func (s *kociembaSearch) run() {
+	twist, flip, slice := s.cc.twist(), s.cc.flip(), s.cc.sliceSorted()
+	for depth := 0; depth <= s.maxLength && !s.stopped; depth++ {
+		s.phase1(twist, flip, slice, 0, depth)
+	}
+}
+
+// expired reports whether the search should give up: either it has a
+// solution and its time is up, or the caller cancelled it
+func (s *kociembaSearch) expired() bool {
+	s.nodes++
+	if s.nodes&0x3ff == 0 {
+		if s.ctx.Err() != nil || (s.best != nil && time.Now().After(s.deadline)) {
+			s.stopped = true
+		}
+	}
+	return s.stopped
+}
+
+// redundant reports whether move m after prev can be skipped: turning the
+// same face twice in a row, or turning opposite faces in both orders.
+func redundant(prev, m int) bool {
+	pf, f := prev/3, m/3
+	return pf == f || pf == f+3
+}
+
+func (s *kociembaSearch) phase1(twist, flip, slice, n, togo int) {
+	if s.expired() {
+		return
+	}
+	t := s.tables
+	if togo == 0 {
+		if twist == 0 && flip == 0 && slice < 24 {
+			// A phase 1 solution ending in a G1 move is a longer version of
+			// one already tried
+			if n == 0 || !isPhase2Move(s.path[n-1]) {
+				s.startPhase2(n)
+			}
+		}
+		return
+	}
+
+	slicePos := slice / 24
+	h := max(t.sliceTwistPrune[slicePos*nTwist+twist], t.sliceFlipPrune[slicePos*nFlip+flip])
+	if int(h) > togo {
+		return
+	}
+
+	for m := 0; m < nMoves; m++ {
+		if n > 0 && redundant(s.path[n-1], m) {
+			continue
+		}
+		s.path[n] = m
+		s.phase1(int(t.twistMove[twist*nMoves+m]), int(t.flipMove[flip*nMoves+m]),
+			int(t.sliceMove[slice*nMoves+m]), n+1, togo-1)
+		if s.stopped {
+			return
+		}
+	}
+}
+
+// startPhase2 searches for the shortest phase 2 continuation of the phase
+// 1 solution in path[:n] that beats the best solution so far
+func (s *kociembaSearch) startPhase2(n int) {
+	cc := s.cc
+	for _, m := range s.path[:n] {
+		cc = cc.Multiply(&moveCubes[m])
+	}
+	corner, edge, slice := cc.cornerPerm(), cc.udEdgePerm(), cc.sliceSorted()
+
+	for depth := 0; n+depth <= s.maxLength; depth++ {
+		if s.phase2(corner, edge, slice, n, depth) {
+			s.best = append(make([]int, 0, n+depth), s.path[:n+depth]...)
+			s.maxLength = n + depth - 1
+			if n+depth <= kociembaTargetLength {
+				s.stopped = true
+			}
+			return
+		}
+	}
+}
+
+func (s *kociembaSearch) phase2(corner, edge, slice, n, togo int) bool {
+	if togo == 0 {
+		return corner == 0 && edge == 0 && slice == 0
+	}
+	t := s.tables
+	h := max(t.cornerPrune[slice*nPerm8+corner], t.udEdgePrune[slice*nPerm8+edge])
+	if int(h) > togo {
+		return false
+	}
+
+	for _, m := range phase2Moves {
+		if n > 0 && redundant(s.path[n-1], m) {
+			continue
+		}
+		s.path[n] = m
+		if s.phase2(int(t.cornerMove[corner*nMoves+m]), int(t.udEdgeMove[edge*nMoves+m]),
+			int(t.sliceMove[slice*nMoves+m]), n+1, togo-1) {
+			return true
+		}
+	}
+	return false
+}
+
+func isPhase2Move(m int) bool {
+	for _, p := range phase2Moves {
+		if p == m {
+			return true
+		}
+	}
+	return false
+}
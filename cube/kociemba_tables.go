@@ -0,0 +1,289 @@
+package cube
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Coordinate sizes used by the two-phase tables
+const (
+	nTwist       = 2187  // 3^7 corner orientations
+	nFlip        = 2048  // 2^11 edge orientations
+	nSliceSorted = 11880 // 12!/8! placements of the E-slice edges
+	nSlice       = 495   // 12 choose 4 positions of the E-slice edges
+	nPerm8       = 40320 // 8! corner or U/D edge permutations
+	nSlicePerm   = 24    // 4! E-slice edge permutations
+)
+
+// TableCacheDir is where generated solver tables are cached between runs.
+// When empty, a directory under os.UserCacheDir is used. If the cache can't
+// be read or written the tables are simply regenerated in memory.
+var TableCacheDir = ""
+
+// kociembaTables are the move and pruning tables of the two-phase solver.
+// Move tables map coordinate*nMoves+move to the new coordinate; pruning
+// tables give the exact distance to the phase goal of a coordinate pair.
+type kociembaTables struct {
+	twistMove  []uint16
+	flipMove   []uint16
+	sliceMove  []uint16 // sliceSorted coordinate
+	cornerMove []uint16
+	udEdgeMove []uint16 // phase 2 moves only
+
+	sliceTwistPrune []uint8 // phase 1: slice position x twist
+	sliceFlipPrune  []uint8 // phase 1: slice position x flip
+	cornerPrune     []uint8 // phase 2: slice permutation x corner permutation
+	udEdgePrune     []uint8 // phase 2: slice permutation x U/D edge permutation
+}
+
+// kociembaCacheMagic identifies the cache file layout; bump it whenever the
+// table contents change
+const kociembaCacheMagic = "kociemba-tables-v1"
+
+var (
+	kociembaOnce  sync.Once
+	kociembaTable *kociembaTables
+)
+
+// loadKociembaTables returns the two-phase tables, reading them from the
+// cache or generating them on first use
+func loadKociembaTables() *kociembaTables {
+	kociembaOnce.Do(func() {
+		path := tableCachePath("kociemba.bin")
+		t, err := readKociembaTables(path)
+		if err != nil {
+			t = generateKociembaTables()
+			_ = writeKociembaTables(path, t)
+		}
+		kociembaTable = t
+	})
+	return kociembaTable
+}
+
+// kociembaTableBytes is the memory the two-phase tables take
+func kociembaTableBytes() int64 {
+	moveTables := (nTwist + nFlip + nSliceSorted + 2*nPerm8) * nMoves
+	pruneTables := nSlice*nTwist + nSlice*nFlip + 2*nSlicePerm*nPerm8
+	return int64(2*moveTables + pruneTables)
+}
+
+// tableCachePath returns the cache file path for name, or "" if there is
+// no usable cache directory
+func tableCachePath(name string) string {
+	dir := TableCacheDir
+	if dir == "" {
+		base, err := os.UserCacheDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(base, "rubiks-cube-solver")
+	}
+	return filepath.Join(dir, name)
+}
+
+func generateKociembaTables() *kociembaTables {
+	allMoves := make([]int, nMoves)
+	for i := range allMoves {
+		allMoves[i] = i
+	}
+
+	t := &kociembaTables{
+		twistMove:  coordMoveTable(nTwist, (*CubieCube).twist, allMoves),
+		flipMove:   coordMoveTable(nFlip, (*CubieCube).flip, allMoves),
+		sliceMove:  coordMoveTable(nSliceSorted, (*CubieCube).sliceSorted, allMoves),
+		cornerMove: coordMoveTable(nPerm8, (*CubieCube).cornerPerm, allMoves),
+		udEdgeMove: coordMoveTable(nPerm8, (*CubieCube).udEdgePerm, phase2Moves),
+	}
+
+	// The slice position is the sliceSorted coordinate divided by 24
+	slicePosMove := make([]uint16, nSlice*nMoves)
+	for pos := 0; pos < nSlice; pos++ {
+		for m := 0; m < nMoves; m++ {
+			slicePosMove[pos*nMoves+m] = t.sliceMove[pos*24*nMoves+m] / 24
+		}
+	}
+
+	t.sliceTwistPrune = pruneTable(nSlice, nTwist, slicePosMove, t.twistMove, allMoves)
+	t.sliceFlipPrune = pruneTable(nSlice, nFlip, slicePosMove, t.flipMove, allMoves)
+	t.cornerPrune = pruneTable(nSlicePerm, nPerm8, t.sliceMove, t.cornerMove, phase2Moves)
+	t.udEdgePrune = pruneTable(nSlicePerm, nPerm8, t.sliceMove, t.udEdgeMove, phase2Moves)
+	return t
+}
+
+// coordMoveTable builds the move table of a coordinate by a breadth-first
+// walk from the solved cube. Any cube with a given coordinate value serves
+// as its representative, since a coordinate's successor depends only on
+// the coordinate itself.
+func coordMoveTable(size int, coord func(*CubieCube) int, moves []int) []uint16 {
+	return coordMoveTableOf[uint16](size, coord, moves)
+}
+
+// coordMoveTableOf is coordMoveTable for coordinates too large for 16 bits
+func coordMoveTableOf[T uint16 | uint32](size int, coord func(*CubieCube) int, moves []int) []T {
+	return moveTableOf[T](size, coord, moveCubes[:], moves)
+}
+
+// moveTableOf is coordMoveTableOf for another set of moves than the 18 face
+// turns, given by their cubie cubes; the table maps
+// coordinate*len(cubies)+move to the new coordinate
+func moveTableOf[T uint16 | uint32](size int, coord func(*CubieCube) int, cubies []CubieCube, moves []int) []T {
+	stride := len(cubies)
+	table := make([]T, size*stride)
+	seen := make([]bool, size)
+	queue := []CubieCube{SolvedCubie()}
+	seen[coord(&queue[0])] = true
+
+	for len(queue) > 0 {
+		cc := queue[0]
+		queue = queue[1:]
+		from := coord(&cc)
+		for _, m := range moves {
+			next := cc.Multiply(&cubies[m])
+			to := coord(&next)
+			table[from*stride+m] = T(to)
+			if !seen[to] {
+				seen[to] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	return table
+}
+
+// pruneTable computes the distance from the solved pair (0, 0) of every
+// pair of two coordinates, indexed i1*n2 + i2
+func pruneTable(n1, n2 int, move1, move2 []uint16, moves []int) []uint8 {
+	return pruneTableFrom(n1, n2, move1, move2, moves, []int{0})
+}
+
+// pruneTableFrom is pruneTable for a phase whose goal is any of several
+// pairs, given by their indices
+func pruneTableFrom[M1, M2 uint16 | uint32](n1, n2 int, move1 []M1, move2 []M2, moves []int, goals []int) []uint8 {
+	return pruneTableOf(nMoves, n1, n2, move1, move2, moves, goals)
+}
+
+// pruneTableOf is pruneTableFrom for move tables made by moveTableOf, with
+// stride moves to each coordinate
+func pruneTableOf[M1, M2 uint16 | uint32](stride, n1, n2 int, move1 []M1, move2 []M2, moves []int, goals []int) []uint8 {
+	const unknown = 0xff
+	table := make([]uint8, n1*n2)
+	for i := range table {
+		table[i] = unknown
+	}
+	for _, g := range goals {
+		table[g] = 0
+	}
+
+	for depth, changed := uint8(0), true; changed; depth++ {
+		changed = false
+		for idx, d := range table {
+			if d != depth {
+				continue
+			}
+			i1, i2 := idx/n2, idx%n2
+			for _, m := range moves {
+				next := int(move1[i1*stride+m])*n2 + int(move2[i2*stride+m])
+				if table[next] == unknown {
+					table[next] = depth + 1
+					changed = true
+				}
+			}
+		}
+	}
+	return table
+}
+
+// fields lists the tables in their cache file order
+func (t *kociembaTables) fields() []any {
+	return []any{
+		t.twistMove, t.flipMove, t.sliceMove, t.cornerMove, t.udEdgeMove,
+		t.sliceTwistPrune, t.sliceFlipPrune, t.cornerPrune, t.udEdgePrune,
+	}
+}
+
+func readKociembaTables(path string) (*kociembaTables, error) {
+	t := &kociembaTables{
+		twistMove:       make([]uint16, nTwist*nMoves),
+		flipMove:        make([]uint16, nFlip*nMoves),
+		sliceMove:       make([]uint16, nSliceSorted*nMoves),
+		cornerMove:      make([]uint16, nPerm8*nMoves),
+		udEdgeMove:      make([]uint16, nPerm8*nMoves),
+		sliceTwistPrune: make([]uint8, nSlice*nTwist),
+		sliceFlipPrune:  make([]uint8, nSlice*nFlip),
+		cornerPrune:     make([]uint8, nSlicePerm*nPerm8),
+		udEdgePrune:     make([]uint8, nSlicePerm*nPerm8),
+	}
+	if err := readTableFile(path, kociembaCacheMagic, t.fields()...); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func writeKociembaTables(path string, t *kociembaTables) error {
+	return writeTableFile(path, kociembaCacheMagic, t.fields()...)
+}
+
+// readTableFile fills fields, in order, from the cache file at path, which
+// must start with magic. Each field is a slice of the right length.
+func readTableFile(path, magic string, fields ...any) error {
+	if path == "" {
+		return errors.New("no cache directory")
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	r := bufio.NewReader(f)
+
+	got := make([]byte, len(magic))
+	if _, err := io.ReadFull(r, got); err != nil || string(got) != magic {
+		return errors.New("stale table cache")
+	}
+	for _, field := range fields {
+		if err := binary.Read(r, binary.LittleEndian, field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeTableFile writes magic followed by fields to the cache file at path
+func writeTableFile(path, magic string, fields ...any) error {
+	if path == "" {
+		return errors.New("no cache directory")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so a concurrent reader never sees a
+	// partial cache
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+"-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	w := bufio.NewWriter(tmp)
+	w.WriteString(magic)
+	for _, field := range fields {
+		if err := binary.Write(w, binary.LittleEndian, field); err != nil {
+			tmp.Close()
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,71 @@
+package cube
+
+import (
+	"os"
+	"testing"
+)
+
+// TestMain caches the solver tables the tests generate in a directory of
+// their own, removed afterwards, rather than the user's cache
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "rubiks-tables-")
+	if err != nil {
+		panic(err)
+	}
+	TableCacheDir = dir
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+func TestSolveKociemba(t *testing.T) {
+	scrambles := []string{
+		"",
+		"R U R' U R U2 R' U",
+		"R U R' U'",
+		"F R U' R' U' R U R' F' R U R' U' R' F R F'",
+		"D2 F' L2 U B' R2 F L' D R U2 B2 L F2 D' R' B U' L2 F R",
+		"U' L B2 D R' F2 U L' B D2 F' R U2 L2 B' D' F R2 U' B",
+	}
+	for _, scramble := range scrambles {
+		c := NewCube()
+		c.ApplyMoves(MustParseMoves(scramble))
+
+		solution, err := SolveKociemba(c)
+		if err != nil {
+			t.Fatalf("%q: %v", scramble, err)
+		}
+		c.ApplyMoves(solution)
+		if !c.IsSolved() {
+			t.Fatalf("%q: solution %s left %s", scramble, FormatMoves(solution), c.KociembaString())
+		}
+	}
+}
+
+func TestSolveKociembaRejectsTwistedCorner(t *testing.T) {
+	c := NewCube()
+	// Twist the URF corner in place
+	u, r, f := c.Sticker(Up, 8), c.Sticker(Right, 0), c.Sticker(Front, 2)
+	c.SetSticker(Up, 8, f)
+	c.SetSticker(Right, 0, u)
+	c.SetSticker(Front, 2, r)
+
+	if _, err := SolveKociemba(c); err == nil {
+		t.Fatal("expected an error for a twisted corner")
+	}
+}
+
+func TestSolveKociembaAnyOrientationAndScheme(t *testing.T) {
+	for _, scheme := range []ColorScheme{Western, Japanese} {
+		c := NewCubeScheme(scheme)
+		c.ApplyMoves(MustParseMoves("R U F' x L2 D z' B y2 M"))
+		solution, err := SolveKociemba(c)
+		if err != nil {
+			t.Fatalf("%s: %v", scheme, err)
+		}
+		c.ApplyMoves(solution)
+		if !c.IsSolved() {
+			t.Fatalf("%s: solution %s left %s", scheme, FormatMoves(solution), c.KociembaString())
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package cube
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Metric is a way of counting the moves in a sequence
+type Metric int
+
+// Move count metrics. Whole-cube rotations only count in ETM.
+const (
+	// HTM (half turn metric): any turn of an outer face or a wide turn is
+	// one move, whatever its angle; a slice turn is two
+	HTM Metric = iota
+	// QTM (quarter turn metric): like HTM but half turns count twice
+	QTM
+	// STM (slice turn metric): any turn of any layer or layers is one move
+	STM
+	// ETM (execution turn metric): every move, rotations included, is one
+	ETM
+)
+
+func (m Metric) String() string {
+	return [...]string{"HTM", "QTM", "STM", "ETM"}[m]
+}
+
+// ParseMetric reads a metric name such as "htm" or "QTM"
+func ParseMetric(s string) (Metric, error) {
+	for m := HTM; m <= ETM; m++ {
+		if strings.EqualFold(s, m.String()) {
+			return m, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown metric %q (want HTM, QTM, STM or ETM)", s)
+}
+
+// Count returns the length of moves in metric m
+func (m Metric) Count(moves []Move) int {
+	n := 0
+	for _, move := range moves {
+		base, turns := splitMove(move)
+		quarters := turns
+		if turns == 3 {
+			quarters = 1
+		}
+		rotation := base == "x" || base == "y" || base == "z"
+		slice := base == "M" || base == "E" || base == "S"
+		switch m {
+		case HTM:
+			switch {
+			case rotation:
+			case slice:
+				n += 2
+			default:
+				n++
+			}
+		case QTM:
+			switch {
+			case rotation:
+			case slice:
+				n += 2 * quarters
+			default:
+				n += quarters
+			}
+		case STM:
+			if !rotation {
+				n++
+			}
+		case ETM:
+			n++
+		}
+	}
+	return n
+}
+
+// splitMove splits a canonical move into its base, e.g. "Rw", and the
+// number of clockwise quarter turns (1-3)
+func splitMove(m Move) (base string, turns int) {
+	switch {
+	case strings.HasSuffix(string(m), "'"):
+		return string(m[:len(m)-1]), 3
+	case strings.HasSuffix(string(m), "2"):
+		return string(m[:len(m)-1]), 2
+	}
+	return string(m), 1
+}
+
+// Simplify cancels and merges moves that turn the same layers, looking past
+// moves on the same axis since those commute: "R L R'" becomes "L", "U U U"
+// becomes "U'" and "R R'" disappears. Moves are otherwise left as written.
+func Simplify(moves []Move) []Move {
+	type turn struct {
+		base  string
+		turns int
+	}
+	var out []turn
+	for _, m := range moves {
+		if _, ok := moveTable[m]; !ok {
+			canonical, err := ParseMove(string(m))
+			if err != nil {
+				continue
+			}
+			m = canonical
+		}
+		base, turns := splitMove(m)
+		axis := moveAxis(base)
+
+		// Look back through the moves on the same axis for one turning the
+		// same layers
+		merged := false
+		for i := len(out) - 1; i >= 0 && moveAxis(out[i].base) == axis; i-- {
+			if out[i].base == base {
+				out[i].turns = (out[i].turns + turns) % 4
+				if out[i].turns == 0 {
+					out = append(out[:i], out[i+1:]...)
+				}
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			out = append(out, turn{base, turns})
+		}
+	}
+
+	result := make([]Move, len(out))
+	for i, t := range out {
+		result[i] = Move(t.base + [...]string{"", "", "2", "'"}[t.turns])
+	}
+	return result
+}
+
+// moveAxis returns 0, 1 or 2 for moves about the x, y and z axes
+func moveAxis(base string) int {
+	n := faceNormals[moveLayers[base].face]
+	for axis, v := range n {
+		if v != 0 {
+			return axis
+		}
+	}
+	return 0
+}
@@ -0,0 +1,80 @@
+package cube
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSimplify(t *testing.T) {
+	tests := []struct{ input, want string }{
+		{"R R'", ""},
+		{"U U U", "U'"},
+		{"R R", "R2"},
+		{"R2 R", "R'"},
+		{"R L R'", "L"},
+		{"R L R", "R2 L"},
+		{"U D2 U' D2", ""},
+		{"R U U' R'", ""},
+		{"R U R'", "R U R'"},
+		{"Rw R'", "Rw R'"},
+		{"M M' x x'", ""},
+		{"R x R", "R2 x"},
+		{"F B F' U", "B U"},
+	}
+	for _, tt := range tests {
+		got := FormatMoves(Simplify(MustParseMoves(tt.input)))
+		if got != tt.want {
+			t.Errorf("Simplify(%s) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestSimplifyKeepsTheEffect(t *testing.T) {
+	s := NewScrambler(3)
+	for i := 0; i < 50; i++ {
+		moves := append(s.RandomMoves(10), MustParseMoves("R L R' L' x M y' E2 Uw D")...)
+		moves = append(moves, Invert(s.RandomMoves(5))...)
+		a, b := NewCube(), NewCube()
+		a.ApplyMoves(moves)
+		b.ApplyMoves(Simplify(moves))
+		if a.faces != b.faces {
+			t.Fatalf("Simplify(%s) = %s changes the result", FormatMoves(moves), FormatMoves(Simplify(moves)))
+		}
+	}
+}
+
+func TestMetricCount(t *testing.T) {
+	tests := []struct {
+		moves              string
+		htm, qtm, stm, etm int
+	}{
+		{"R U2 F'", 3, 4, 3, 3},
+		{"M2 E S'", 6, 8, 3, 3},
+		{"Rw2 r'", 2, 3, 2, 2},
+		{"x y2 z' R", 1, 1, 1, 4},
+		{"", 0, 0, 0, 0},
+	}
+	for _, tt := range tests {
+		moves := MustParseMoves(tt.moves)
+		for metric, want := range map[Metric]int{HTM: tt.htm, QTM: tt.qtm, STM: tt.stm, ETM: tt.etm} {
+			if got := metric.Count(moves); got != want {
+				t.Errorf("%s.Count(%s) = %d, want %d", metric, tt.moves, got, want)
+			}
+		}
+	}
+}
+
+func TestParseMetric(t *testing.T) {
+	for _, name := range []string{"htm", "QTM", "Stm", "ETM"} {
+		m, err := ParseMetric(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.EqualFold(m.String(), name) {
+			t.Errorf("ParseMetric(%q) = %s", name, m)
+		}
+	}
+	if _, err := ParseMetric("OBTM"); err == nil {
+		t.Error("ParseMetric accepted an unknown metric")
+	}
+}
@@ -0,0 +1,150 @@
+package cube
+
+import (
+	"slices"
+	"strings"
+)
+
+// Move represents a cube move in canonical notation (see ParseMove), e.g.
+// "R", "U'", "F2", "Rw", "M'" or "y"
+type Move string
+
+const (
+	R  Move = "R"  // Right clockwise
+	Ri Move = "R'" // Right counter-clockwise
+	R2 Move = "R2" // Right half turn
+	L  Move = "L"
+	Li Move = "L'"
+	L2 Move = "L2"
+	U  Move = "U"
+	Ui Move = "U'"
+	U2 Move = "U2"
+	D  Move = "D"
+	Di Move = "D'"
+	D2 Move = "D2"
+	F  Move = "F"
+	Fi Move = "F'"
+	F2 Move = "F2"
+	B  Move = "B"
+	Bi Move = "B'"
+	B2 Move = "B2"
+)
+
+// AllMoves lists every quarter-turn move
+var AllMoves = []Move{R, Ri, L, Li, U, Ui, D, Di, F, Fi, B, Bi}
+
+// Inverse returns the reverse of a move
+func (m Move) Inverse() Move {
+	switch {
+	case strings.HasSuffix(string(m), "'"):
+		return m[:len(m)-1]
+	case strings.HasSuffix(string(m), "2"):
+		return m
+	}
+	return m + "'"
+}
+
+// Invert returns the sequence that undoes moves: the inverse of every move
+// in reverse order.
+func Invert(moves []Move) []Move {
+	inverse := make([]Move, 0, len(moves))
+	for i := len(moves) - 1; i >= 0; i-- {
+		inverse = append(inverse, moves[i].Inverse())
+	}
+	return inverse
+}
+
+// ApplyMove performs a move on the cube. Moves not in canonical form are
+// normalised first; anything that isn't a move is ignored.
+func (c *Cube) ApplyMove(m Move) {
+	p, ok := moveTable[m]
+	if !ok {
+		canonical, err := ParseMove(string(m))
+		if err != nil {
+			return
+		}
+		p = moveTable[canonical]
+	}
+	c.permute(p)
+}
+
+// ApplyMoves performs a sequence of moves on the cube
+func (c *Cube) ApplyMoves(moves []Move) {
+	for _, move := range moves {
+		c.ApplyMove(move)
+	}
+}
+
+// Every move turns one or more layers perpendicular to a face's axis, in
+// the direction that face turns. Layers are numbered by how far they sit
+// along the face's normal: 1 is the face itself, 0 the slice next to it.
+var moveLayers = map[string]struct {
+	face   Face
+	layers []int
+}{
+	"U": {Up, []int{1}}, "R": {Right, []int{1}}, "F": {Front, []int{1}},
+	"D": {Down, []int{1}}, "L": {Left, []int{1}}, "B": {Back, []int{1}},
+	"Uw": {Up, []int{1, 0}}, "Rw": {Right, []int{1, 0}}, "Fw": {Front, []int{1, 0}},
+	"Dw": {Down, []int{1, 0}}, "Lw": {Left, []int{1, 0}}, "Bw": {Back, []int{1, 0}},
+	"M": {Left, []int{0}}, "E": {Down, []int{0}}, "S": {Front, []int{0}},
+	"x": {Right, []int{1, 0, -1}}, "y": {Up, []int{1, 0, -1}}, "z": {Front, []int{1, 0, -1}},
+}
+
+// Layers describes how m turns the cube, for drawing it part way through:
+// the layers it turns lie at depths along face's outward normal (1 is the
+// face itself, 0 the middle slice and -1 the opposite face), and they turn
+// by quarters clockwise quarter turns as seen looking at face, -1 for a
+// prime. ok is false if m isn't a move.
+func (m Move) Layers() (face Face, depths []int, quarters int, ok bool) {
+	canonical, err := ParseMove(string(m))
+	if err != nil {
+		return 0, nil, 0, false
+	}
+	base, turns := splitMove(canonical)
+	if turns == 3 {
+		turns = -1
+	}
+	ml := moveLayers[base]
+	return ml.face, slices.Clone(ml.layers), turns, true
+}
+
+// moveTable holds the sticker permutation of every canonical move
+var moveTable = func() map[Move]*stickerPerm {
+	table := make(map[Move]*stickerPerm, len(moveLayers)*3)
+	for base, ml := range moveLayers {
+		n := faceNormals[ml.face]
+		turn := faceTurn(ml.face)
+		inLayer := func(pos vec3) bool {
+			depth := pos[0]*n[0] + pos[1]*n[1] + pos[2]*n[2]
+			for _, l := range ml.layers {
+				if depth == l {
+					return true
+				}
+			}
+			return false
+		}
+		for q, suffix := range []string{"", "2", "'"} {
+			table[Move(base+suffix)] = permFor(turn.times(q+1), inLayer)
+		}
+	}
+	return table
+}()
+
+// faceTurn returns the rotation of a clockwise quarter turn of face f, as
+// seen looking at that face
+func faceTurn(f Face) rotation {
+	switch f {
+	case Right:
+		return rotationX
+	case Left:
+		return rotationX.inverse()
+	case Up:
+		return rotationY
+	case Down:
+		return rotationY.inverse()
+	case Front:
+		return rotationZ
+	default: // Back
+		return rotationZ.inverse()
+	}
+}
@@ -0,0 +1,320 @@
+package cube
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Move notation, following the WCA regulations plus the common extensions
+// used by algorithm databases:
+//
+//	R U F D L B       face turns; R' counter-clockwise, R2 (or R2') half turn
+//	Rw r              wide turns: the face and the slice next to it
+//	M E S             slices, turning like L, D and F respectively
+//	x y z             whole-cube rotations, turning like R, U and F
+//	(R U R' U')3      groups, optionally repeated or inverted: (R U)'
+//	[R U R', D]       commutator A B A' B'
+//	[U: R U R']       conjugate A B A'; brackets nest and may be repeated
+//	// comment        comments run to the end of the line; /* ... */ also works
+//
+// Moves may be separated by spaces or written together, as in "RUR'U'".
+
+// SyntaxError reports where a move string could not be parsed
+type SyntaxError struct {
+	Offset int // byte offset into the input
+	Line   int // 1-based line
+	Column int // 1-based column, counted in characters
+	Msg    string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Msg)
+}
+
+// ParseMoves parses a move sequence such as "R U2 F'" or a scramble or
+// algorithm using any of the notation above. Groups, commutators and
+// conjugates are expanded, so the result holds only single moves, each in
+// canonical form (see ParseMove).
+func ParseMoves(s string) ([]Move, error) {
+	alg, err := ParseAlg(s)
+	if err != nil {
+		return nil, err
+	}
+	return alg.Expand(), nil
+}
+
+// ParseAlg parses an algorithm, keeping its groups, commutators and
+// conjugates
+func ParseAlg(s string) (Alg, error) {
+	p := &moveParser{src: s}
+	alg, err := p.sequence("")
+	if err != nil {
+		return nil, err
+	}
+	return alg, nil
+}
+
+// MustParseAlg is like ParseAlg but panics on a syntax error
+func MustParseAlg(s string) Alg {
+	alg, err := ParseAlg(s)
+	if err != nil {
+		panic("cube: MustParseAlg(" + s + "): " + err.Error())
+	}
+	return alg
+}
+
+// MustParseMoves is like ParseMoves but panics on a syntax error. It is
+// meant for algorithms written into the program.
+func MustParseMoves(s string) []Move {
+	moves, err := ParseMoves(s)
+	if err != nil {
+		panic("cube: MustParseMoves(" + s + "): " + err.Error())
+	}
+	return moves
+}
+
+// ParseMove parses a single move and returns it in canonical form: wide
+// moves written as Rw rather than r, and half turns as R2 rather than R2'
+func ParseMove(s string) (Move, error) {
+	moves, err := ParseMoves(s)
+	if err != nil {
+		return "", err
+	}
+	if len(moves) != 1 {
+		return "", &SyntaxError{Line: 1, Column: 1, Msg: fmt.Sprintf("%q is not a single move", s)}
+	}
+	return moves[0], nil
+}
+
+// FormatMoves renders a move sequence in standard space separated notation
+func FormatMoves(moves []Move) string {
+	tokens := make([]string, len(moves))
+	for i, move := range moves {
+		tokens[i] = string(move)
+	}
+	return strings.Join(tokens, " ")
+}
+
+// moveParser is a recursive descent parser over a move string
+type moveParser struct {
+	src string
+	pos int
+}
+
+// errorAt returns a SyntaxError for byte offset pos
+func (p *moveParser) errorAt(pos int, format string, args ...any) error {
+	line, col := 1, 1
+	for _, r := range p.src[:pos] {
+		if r == '\n' {
+			line, col = line+1, 1
+		} else {
+			col++
+		}
+	}
+	return &SyntaxError{Offset: pos, Line: line, Column: col, Msg: fmt.Sprintf(format, args...)}
+}
+
+// peek returns the next character, or 0 at the end of the input
+func (p *moveParser) peek() rune {
+	if p.pos >= len(p.src) {
+		return 0
+	}
+	r, _ := utf8.DecodeRuneInString(p.src[p.pos:])
+	return r
+}
+
+func (p *moveParser) next() rune {
+	r, size := utf8.DecodeRuneInString(p.src[p.pos:])
+	p.pos += size
+	return r
+}
+
+// skipSpace skips whitespace and comments
+func (p *moveParser) skipSpace() error {
+	for p.pos < len(p.src) {
+		switch rest := p.src[p.pos:]; {
+		case strings.HasPrefix(rest, "//"):
+			end := strings.IndexByte(rest, '\n')
+			if end < 0 {
+				end = len(rest)
+			}
+			p.pos += end
+		case strings.HasPrefix(rest, "/*"):
+			end := strings.Index(rest[2:], "*/")
+			if end < 0 {
+				return p.errorAt(p.pos, "unterminated comment")
+			}
+			p.pos += end + 4
+		case strings.ContainsRune(" \t\r\n", p.peek()):
+			p.pos++
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+// sequence parses moves, groups and brackets up to the end of the input or
+// one of the characters in stop, which closes the enclosing group or bracket
+func (p *moveParser) sequence(stop string) (Alg, error) {
+	var items Sequence
+	var run Moves
+	flush := func() {
+		if len(run) > 0 {
+			items = append(items, run)
+			run = nil
+		}
+	}
+	for {
+		if err := p.skipSpace(); err != nil {
+			return nil, err
+		}
+		switch r := p.peek(); {
+		case r == 0 || strings.ContainsRune(stop, r):
+			flush()
+			switch len(items) {
+			case 0:
+				return Moves(nil), nil
+			case 1:
+				return items[0], nil
+			}
+			return items, nil
+		case strings.ContainsRune(")],:", r):
+			return nil, p.errorAt(p.pos, "unexpected '%c'", r)
+		case r == '(' || r == '[':
+			flush()
+			item, err := p.group()
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		default:
+			m, err := p.move()
+			if err != nil {
+				return nil, err
+			}
+			run = append(run, m)
+		}
+	}
+}
+
+// group parses a parenthesised sequence, commutator or conjugate with an
+// optional repeat count and prime, e.g. (R U R' U')3, (R U)' or [R, U]2
+func (p *moveParser) group() (Alg, error) {
+	open := p.pos
+	var alg Alg
+	if p.next() == '(' {
+		inner, err := p.sequence(")")
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ')' {
+			return nil, p.errorAt(open, "unclosed '('")
+		}
+		p.next()
+		alg = inner
+	} else {
+		a, err := p.sequence(",:]")
+		if err != nil {
+			return nil, err
+		}
+		sep := p.peek()
+		if sep != ',' && sep != ':' {
+			if sep == 0 {
+				return nil, p.errorAt(open, "unclosed '['")
+			}
+			return nil, p.errorAt(p.pos, "expected ',' or ':' in '['")
+		}
+		p.next()
+		b, err := p.sequence("]")
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ']' {
+			return nil, p.errorAt(open, "unclosed '['")
+		}
+		p.next()
+		if sep == ',' {
+			alg = Commutator{A: a, B: b}
+		} else {
+			alg = Conjugate{A: a, B: b}
+		}
+	}
+
+	count := 1
+	if n, ok, err := p.number(); err != nil {
+		return nil, err
+	} else if ok {
+		count = n
+	}
+	inverse := p.prime()
+	if count == 1 && !inverse {
+		return alg, nil
+	}
+	return Repeat{Alg: alg, Count: count, Inverse: inverse}, nil
+}
+
+// move parses a single move with its amount and direction
+func (p *moveParser) move() (Move, error) {
+	start := p.pos
+	r := p.next()
+	var base string
+	switch {
+	case strings.ContainsRune("URFDLB", r):
+		base = string(r)
+		if p.peek() == 'w' {
+			p.next()
+			base += "w"
+		}
+	case strings.ContainsRune("urfdlb", r):
+		base = strings.ToUpper(string(r)) + "w"
+	case strings.ContainsRune("MESxyz", r):
+		base = string(r)
+	default:
+		return "", p.errorAt(start, "unexpected %q", r)
+	}
+
+	turns := 1
+	if n, ok, err := p.number(); err != nil {
+		return "", err
+	} else if ok {
+		turns = n % 4
+		if turns == 0 {
+			return "", p.errorAt(start, "%s turns the layer a whole number of times round", p.src[start:p.pos])
+		}
+	}
+	if p.prime() {
+		turns = 4 - turns
+	}
+	return Move(base + [...]string{"", "", "2", "'"}[turns]), nil
+}
+
+// number parses an optional positive decimal count
+func (p *moveParser) number() (n int, ok bool, err error) {
+	start := p.pos
+	for r := p.peek(); r >= '0' && r <= '9'; r = p.peek() {
+		p.next()
+		n = n*10 + int(r-'0')
+		if n > 1000 {
+			return 0, false, p.errorAt(start, "count too large")
+		}
+	}
+	if p.pos == start {
+		return 0, false, nil
+	}
+	if n == 0 {
+		return 0, false, p.errorAt(start, "count must be at least 1")
+	}
+	return n, true, nil
+}
+
+// prime consumes an optional prime, accepting the typographic apostrophe
+// that word processors substitute
+func (p *moveParser) prime() bool {
+	if r := p.peek(); r == '\'' || r == '’' {
+		p.next()
+		return true
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+package cube
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestParseMoves(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"R U2 F'", "R U2 F'"},
+		{"R2' R’ R3", "R2 R' R'"},
+		{"Rw r2 l' Uw' d", "Rw Rw2 Lw' Uw' Dw"},
+		{"M' E S2 x y' z2", "M' E S2 x y' z2"},
+		{"RUR'U'", "R U R' U'"},
+		{"(R U)3", "R U R U R U"},
+		{"(R U F)'", "F' U' R'"},
+		{"((R U)2 F)2", "R U R U F R U R U F"},
+		{"R // first move\nU /* second */ F", "R U F"},
+		{"  ", ""},
+	}
+	for _, tt := range tests {
+		moves, err := ParseMoves(tt.input)
+		if err != nil {
+			t.Errorf("ParseMoves(%q): %v", tt.input, err)
+			continue
+		}
+		if got := FormatMoves(moves); got != tt.want {
+			t.Errorf("ParseMoves(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseMovesSyntaxErrors(t *testing.T) {
+	tests := []struct {
+		input        string
+		line, column int
+	}{
+		{"R Q", 1, 3},
+		{"(R U", 1, 1},
+		{"R U)", 1, 4},
+		{"R4", 1, 1},
+		{"R U\nF G'", 2, 3},
+		{"R /* open", 1, 3},
+		{"(R U)0", 1, 6},
+	}
+	for _, tt := range tests {
+		_, err := ParseMoves(tt.input)
+		var serr *SyntaxError
+		if !errors.As(err, &serr) {
+			t.Errorf("ParseMoves(%q) error = %v, want a *SyntaxError", tt.input, err)
+			continue
+		}
+		if serr.Line != tt.line || serr.Column != tt.column {
+			t.Errorf("ParseMoves(%q) error at %d:%d, want %d:%d (%v)", tt.input, serr.Line, serr.Column, tt.line, tt.column, err)
+		}
+	}
+}
+
+func TestMoveEquivalences(t *testing.T) {
+	tests := []struct{ a, b string }{
+		{"Rw", "R M'"},
+		{"Lw", "L M"},
+		{"Uw", "U E'"},
+		{"Dw", "D E"},
+		{"Fw", "F S"},
+		{"Bw", "B S'"},
+		{"x", "R M' L'"},
+		{"y", "U E' D'"},
+		{"z", "F S B'"},
+		{"x U x'", "F"},
+		{"y R y'", "B"},
+		{"M2", "M M"},
+	}
+	for _, tt := range tests {
+		a, b := NewCube(), NewCube()
+		a.ApplyMoves(MustParseMoves(tt.a))
+		b.ApplyMoves(MustParseMoves(tt.b))
+		if a.faces != b.faces {
+			t.Errorf("%s and %s give different cubes", tt.a, tt.b)
+		}
+	}
+}
+
+func TestInvertAllMoveKinds(t *testing.T) {
+	alg := MustParseMoves("R U2 Rw' M E2 S' x y2 z' Bw2 d")
+	c := NewCube()
+	c.ApplyMoves(alg)
+	c.ApplyMoves(Invert(alg))
+	if !c.IsSolved() {
+		t.Fatalf("%s followed by its inverse left %s", FormatMoves(alg), c.KociembaString())
+	}
+	if !slices.Equal(Invert(Invert(alg)), alg) {
+		t.Fatal("inverting twice does not give the original sequence")
+	}
+}
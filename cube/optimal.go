@@ -0,0 +1,171 @@
+package cube
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Optimal solving, after Korf: iterative-deepening A* (IDA*) with pattern
+// databases as the heuristic (see optimal_tables.go). Each iteration is a
+// depth-first search that gives up on a branch as soon as the moves made so
+// far plus the pattern database bound exceed the iteration's depth, and the
+// depth grows by one (or two, see below) until a solution turns up. Since
+// the bound never overestimates, the first solution found is the shortest.
+//
+// Random cubes need 17-20 moves, and proving there is nothing shorter takes
+// minutes to hours depending on the tables; short scrambles are solved at
+// once.
+
+// optimalMaxDepth is beyond God's number in both metrics (20 HTM, 26 QTM)
+const optimalMaxDepth = 26
+
+// SolveOptimal returns a shortest solution in metric (HTM or QTM), using
+// the default memory budget for its tables
+func SolveOptimal(ctx context.Context, c *Cube, metric Metric) ([]Move, error) {
+	return optimalSolver{metric: metric}.solve(ctx, c)
+}
+
+// optimalSolver is the registry adapter for SolveOptimal
+type optimalSolver struct {
+	metric   Metric
+	budget   int64
+	progress func(status string)
+	restrict *restriction
+}
+
+func init() {
+	Register("optimal", func(opts Options) Solver {
+		return optimalSolver{
+			metric:   opts.Metric,
+			budget:   opts.MemoryBudget,
+			progress: opts.Progress,
+			restrict: restrictionOf(opts),
+		}
+	})
+}
+
+func (optimalSolver) Name() string { return "optimal" }
+
+func (optimalSolver) Capabilities() Capabilities {
+	return Capabilities{Optimal: true, MoveSets: true}
+}
+
+func (s optimalSolver) Solve(ctx context.Context, c *Cube) (Solution, error) {
+	if s.restrict != nil {
+		return s.restrict.solve(ctx, c, s.Name())
+	}
+	moves, err := s.solve(ctx, c)
+	if err != nil {
+		return Solution{}, err
+	}
+	return Solution{Solver: s.Name(), Moves: moves}, nil
+}
+
+func (s optimalSolver) solve(ctx context.Context, c *Cube) ([]Move, error) {
+	if s.metric != HTM && s.metric != QTM {
+		return nil, fmt.Errorf("optimal: can only minimise HTM or QTM, not %s", s.metric)
+	}
+	if err := c.Validate(); err != nil {
+		return nil, fmt.Errorf("optimal: %w", err)
+	}
+	cc, err := cubieFromFacelets(c.KociembaString())
+	if err != nil {
+		return nil, fmt.Errorf("optimal: %v", err)
+	}
+
+	budget := s.budget
+	if budget == 0 {
+		budget = DefaultMemoryBudget
+	}
+	s.report("loading pattern databases…")
+	tables, err := loadOptimalTables(s.metric, budget)
+	if err != nil {
+		return nil, fmt.Errorf("optimal: %v", err)
+	}
+
+	search := &optimalSearch{ctx: ctx, tables: tables, metric: s.metric}
+	start := newOptimalState(&cc)
+	depth := tables.bound(&start)
+	step := 1
+	if s.metric == QTM {
+		// Every quarter turn is an odd permutation of the corners, so the
+		// length of any solution has the parity of the corner permutation
+		step = 2
+		if depth%2 != permParity(cc.CP[:]) {
+			depth++
+		}
+	}
+	for ; depth <= optimalMaxDepth; depth += step {
+		s.report(fmt.Sprintf("searching depth %d…", depth))
+		if search.dfs(&start, depth, -1) {
+			names := make([]string, len(search.path))
+			for i, m := range search.path {
+				names[len(names)-1-i] = moveNames[m]
+			}
+			moves := MustParseMoves(strings.Join(names, " "))
+			if err := checkSolution(c, moves); err != nil {
+				return nil, fmt.Errorf("optimal: %v", err)
+			}
+			return moves, nil
+		}
+		if search.stopped {
+			return nil, ctx.Err()
+		}
+	}
+	return nil, fmt.Errorf("optimal: no solution within %d moves", optimalMaxDepth)
+}
+
+func (s optimalSolver) report(status string) {
+	if s.progress != nil {
+		s.progress(status)
+	}
+}
+
+// optimalSearch holds the state of one IDA* search
+type optimalSearch struct {
+	ctx     context.Context
+	tables  *optimalTables
+	metric  Metric
+	path    []int // the solution's moves, last first
+	nodes   int
+	stopped bool
+}
+
+// dfs looks for a solution of s within togo moves (counted in the search's
+// metric), not starting with a move redundant after prev. On success the
+// solution is in search.path.
+func (search *optimalSearch) dfs(s *optimalState, togo, prev int) bool {
+	search.nodes++
+	if search.nodes&0xfff == 0 && search.ctx.Err() != nil {
+		search.stopped = true
+	}
+	if search.stopped {
+		return false
+	}
+	if togo == 0 {
+		return s.solved()
+	}
+	if search.tables.bound(s) > togo {
+		return false
+	}
+
+	for m := 0; m < nMoves; m++ {
+		if prev >= 0 && redundant(prev, m) {
+			continue
+		}
+		cost := 1
+		if search.metric == QTM && m%3 == 1 {
+			cost = 2
+		}
+		if cost > togo {
+			continue
+		}
+		next := search.tables.move(s, m)
+		if search.dfs(&next, togo-cost, m) {
+			search.path = append(search.path, m)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,387 @@
+package cube
+
+import (
+	"fmt"
+	"math/bits"
+	"sync"
+)
+
+// Pattern databases for the optimal solver. Each gives the exact number of
+// moves needed to solve part of the cube (the corners, or a group of
+// edges), which is a lower bound on the moves needed for the whole cube.
+// Distances are stored in four bits per entry.
+//
+// The edge table covers every state of its group of edges. Turning the
+// whole cube by x2 takes the group to a different group and keeps every
+// edge's orientation, so the x2-conjugated cube is looked up in the same
+// table too, bounding the distance of the other group for the cost of a
+// second lookup rather than a second table.
+
+// Pattern database sizes
+const (
+	nCornerStates = nPerm8 * nTwist // 88,179,840 corner states
+	nEdges        = 12
+)
+
+// DefaultMemoryBudget is the memory the optimal solver's tables use unless
+// Options.MemoryBudget says otherwise: enough for the two-phase tables, the
+// full corner table and a six edge table
+const DefaultMemoryBudget = 80 << 20
+
+// optimalCacheMagic identifies the pattern database cache file layout
+const optimalCacheMagic = "optimal-pdb-v1"
+
+// edgeGroup is the edges an edge table tracks
+type edgeGroup []Edge
+
+// edgeGroupOrder lists the edges a table of k edges tracks: the first k
+var edgeGroupOrder = edgeGroup{UR, UF, UL, UB, FR, FL, BL}
+
+// maxEdgeGroup is the most edges a table tracks
+const maxEdgeGroup = 7
+
+// size is the number of entries of a table of the group: placements of
+// its edges times their flips
+func (g edgeGroup) size() int {
+	n := 1
+	for i := range g {
+		n *= nEdges - i
+	}
+	return n << len(g)
+}
+
+// placementIndex ranks the positions of the group's edges, pos[i] being
+// where edge g[i] is
+func placementIndex(pos []int8) int {
+	var used uint16
+	idx := 0
+	for i, p := range pos {
+		smaller := bits.OnesCount16(used & (1<<p - 1))
+		idx = idx*(nEdges-i) + int(p) - smaller
+		used |= 1 << p
+	}
+	return idx
+}
+
+// placementFromIndex is the inverse of placementIndex
+func placementFromIndex(idx int, pos []int8) {
+	k := len(pos)
+	var digits [maxEdgeGroup]int
+	for i := k - 1; i >= 0; i-- {
+		digits[i] = idx % (nEdges - i)
+		idx /= nEdges - i
+	}
+	var used uint16
+	for i, d := range digits[:k] {
+		// The d-th unused position
+		for p := 0; p < nEdges; p++ {
+			if used&(1<<p) != 0 {
+				continue
+			}
+			if d == 0 {
+				pos[i] = int8(p)
+				used |= 1 << p
+				break
+			}
+			d--
+		}
+	}
+}
+
+// nibbles is a table of 4-bit values
+type nibbles []byte
+
+const nibbleUnknown = 0xf
+
+func newNibbles(n int) nibbles {
+	t := make(nibbles, (n+1)/2)
+	for i := range t {
+		t[i] = 0xff
+	}
+	return t
+}
+
+func (t nibbles) get(i int) uint8 {
+	return t[i>>1] >> (uint(i&1) << 2) & 0xf
+}
+
+func (t nibbles) set(i int, v uint8) {
+	shift := uint(i&1) << 2
+	t[i>>1] = t[i>>1]&^(0xf<<shift) | v<<shift
+}
+
+// fillUnknown caps the entries a breadth-first search didn't reach, so a
+// table can stop before its deepest states and stay a lower bound
+func (t nibbles) fillUnknown(n int, v uint8) {
+	for i := 0; i < n; i++ {
+		if t.get(i) == nibbleUnknown {
+			t.set(i, v)
+		}
+	}
+}
+
+// Moves per coordinate step are looked up in the move tables shared with
+// the two-phase solver; metricMoves lists the moves one step may be
+func metricMoves(metric Metric) []int {
+	var moves []int
+	for m := 0; m < nMoves; m++ {
+		if metric == QTM && m%3 == 1 {
+			continue // a half turn is two quarter turns
+		}
+		moves = append(moves, m)
+	}
+	return moves
+}
+
+// optimalTables are the pattern databases used for one metric and budget
+type optimalTables struct {
+	// corners is indexed by cornerPerm*nTwist + twist; when the budget
+	// doesn't stretch to it, the permutation and twist have separate
+	// (weaker) tables instead
+	corners    nibbles
+	cornerPerm []uint8
+	twist      []uint8
+
+	edges      edgeGroup
+	edgeTable  nibbles
+	edgeMirror [nEdges]int8 // x2: where each edge position goes
+
+	kt *kociembaTables // corner move tables
+
+	// edgeTo[m][p] is where move m takes an edge at position p, and
+	// edgeFlip[m][p] whether it flips it
+	edgeTo   [nMoves][nEdges]int8
+	edgeFlip [nMoves][nEdges]uint8
+}
+
+// optimalPlan picks the tables that fit in budget bytes. The two-phase
+// tables come first, as the corner move tables are taken from them; then
+// the corner table if it leaves room for at least five edges, and the
+// largest edge group that fits in what remains.
+func optimalPlan(budget int64) (corners bool, edges int, err error) {
+	cornerBytes := int64(nCornerStates / 2)
+	edgeBytes := func(k int) int64 { return int64(edgeGroupOrder[:k].size() / 2) }
+	tables := budget - kociembaTableBytes()
+	left := tables - (nPerm8 + nTwist)
+	if tables >= cornerBytes+edgeBytes(5) {
+		corners = true
+		left = tables - cornerBytes
+	}
+	for k := maxEdgeGroup; k >= 4; k-- {
+		if edgeBytes(k) <= left {
+			return corners, k, nil
+		}
+	}
+	return false, 0, fmt.Errorf("memory budget of %d bytes is too small for the optimal solver's tables", budget)
+}
+
+var (
+	optimalTablesMu sync.Mutex
+	optimalCache    = map[string]*optimalTables{}
+)
+
+// loadOptimalTables returns the tables for a metric and memory budget,
+// generating and caching them on first use
+func loadOptimalTables(metric Metric, budget int64) (*optimalTables, error) {
+	corners, k, err := optimalPlan(budget)
+	if err != nil {
+		return nil, err
+	}
+
+	optimalTablesMu.Lock()
+	defer optimalTablesMu.Unlock()
+	key := fmt.Sprintf("%s-corners%t-edges%d", metric, corners, k)
+	if t, ok := optimalCache[key]; ok {
+		return t, nil
+	}
+
+	kt := loadKociembaTables()
+	moves := metricMoves(metric)
+	t := &optimalTables{edges: edgeGroupOrder[:k], kt: kt}
+	x2 := moveCubies["x2"]
+	for i, p := range x2.EP {
+		t.edgeMirror[p] = int8(i)
+	}
+	for m := range moveCubes {
+		for i, p := range moveCubes[m].EP {
+			t.edgeTo[m][p] = int8(i)
+			t.edgeFlip[m][p] = uint8(moveCubes[m].EO[i])
+		}
+	}
+
+	if corners {
+		t.corners = newNibbles(nCornerStates)
+		path := tableCachePath(fmt.Sprintf("optimal-%s-corners.bin", metric))
+		if readTableFile(path, optimalCacheMagic, []byte(t.corners)) != nil {
+			t.corners = newNibbles(nCornerStates)
+			cornerTable(t.corners, kt, moves)
+			_ = writeTableFile(path, optimalCacheMagic, []byte(t.corners))
+		}
+	} else {
+		t.cornerPerm = pruneTable(1, nPerm8, make([]uint16, nMoves), kt.cornerMove, moves)
+		t.twist = pruneTable(1, nTwist, make([]uint16, nMoves), kt.twistMove, moves)
+	}
+
+	size := t.edges.size()
+	t.edgeTable = newNibbles(size)
+	path := tableCachePath(fmt.Sprintf("optimal-%s-edges%d.bin", metric, k))
+	if readTableFile(path, optimalCacheMagic, []byte(t.edgeTable)) != nil {
+		t.edgeTable = newNibbles(size)
+		t.generateEdgeTable(moves)
+		_ = writeTableFile(path, optimalCacheMagic, []byte(t.edgeTable))
+	}
+
+	optimalCache[key] = t
+	return t, nil
+}
+
+// cornerTable fills in the distance of every corner state by a breadth-
+// first search from the solved corners
+func cornerTable(table nibbles, kt *kociembaTables, moves []int) {
+	table.set(0, 0)
+	for depth := uint8(0); depth < nibbleUnknown-1; depth++ {
+		changed := false
+		for idx := 0; idx < nCornerStates; idx++ {
+			if table.get(idx) != depth {
+				continue
+			}
+			perm, twist := idx/nTwist, idx%nTwist
+			for _, m := range moves {
+				next := int(kt.cornerMove[perm*nMoves+m])*nTwist + int(kt.twistMove[twist*nMoves+m])
+				if table.get(next) == nibbleUnknown {
+					table.set(next, depth+1)
+					changed = true
+				}
+			}
+		}
+		if !changed {
+			return
+		}
+	}
+	table.fillUnknown(nCornerStates, nibbleUnknown-1)
+}
+
+// generateEdgeTable fills in the distance of every placement and flip of
+// the tracked edges. The placement is decoded once for all its flips,
+// since a move changes the flips by the same mask whatever they are.
+func (t *optimalTables) generateEdgeTable(moves []int) {
+	k := len(t.edges)
+	flips := 1 << k
+	placements := t.edges.size() >> k
+	table := t.edgeTable
+
+	// The group's edges home and unflipped
+	home := make([]int8, k)
+	for i, e := range t.edges {
+		home[i] = int8(e)
+	}
+	table.set(placementIndex(home)<<k, 0)
+
+	pos := make([]int8, k)
+	next := make([]int8, k)
+	for depth := uint8(0); depth < nibbleUnknown-1; depth++ {
+		changed := false
+		for pl := 0; pl < placements; pl++ {
+			base := pl << k
+			frontier := false
+			for f := 0; f < flips && !frontier; f++ {
+				frontier = table.get(base+f) == depth
+			}
+			if !frontier {
+				continue
+			}
+			placementFromIndex(pl, pos)
+			for _, m := range moves {
+				mask := 0
+				for i, p := range pos {
+					next[i] = t.edgeTo[m][p]
+					mask |= int(t.edgeFlip[m][p]) << i
+				}
+				nextBase := placementIndex(next) << k
+				for f := 0; f < flips; f++ {
+					if table.get(base+f) != depth {
+						continue
+					}
+					if to := nextBase + (f ^ mask); table.get(to) == nibbleUnknown {
+						table.set(to, depth+1)
+						changed = true
+					}
+				}
+			}
+		}
+		if !changed {
+			return
+		}
+	}
+	table.fillUnknown(t.edges.size(), nibbleUnknown-1)
+}
+
+// optimalState is the cube as the optimal search tracks it: the corner
+// coordinates of the two-phase solver, and where each edge is
+type optimalState struct {
+	cornerPerm, twist uint16
+	edgePos           [nEdges]int8  // edgePos[e] is where edge e is
+	edgeFlip          [nEdges]uint8 // and whether it is flipped there
+}
+
+func newOptimalState(cc *CubieCube) optimalState {
+	s := optimalState{cornerPerm: uint16(cc.cornerPerm()), twist: uint16(cc.twist())}
+	for i, e := range cc.EP {
+		s.edgePos[e] = int8(i)
+		s.edgeFlip[e] = uint8(cc.EO[i])
+	}
+	return s
+}
+
+func (s *optimalState) solved() bool {
+	if s.cornerPerm != 0 || s.twist != 0 {
+		return false
+	}
+	for e := range s.edgePos {
+		if s.edgePos[e] != int8(e) || s.edgeFlip[e] != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// move returns the state after move m
+func (t *optimalTables) move(s *optimalState, m int) optimalState {
+	next := optimalState{
+		cornerPerm: t.kt.cornerMove[int(s.cornerPerm)*nMoves+m],
+		twist:      t.kt.twistMove[int(s.twist)*nMoves+m],
+	}
+	for e, p := range s.edgePos {
+		next.edgePos[e] = t.edgeTo[m][p]
+		next.edgeFlip[e] = s.edgeFlip[e] ^ t.edgeFlip[m][p]
+	}
+	return next
+}
+
+// bound returns a lower bound on the moves needed to solve s: the largest
+// of the pattern database entries for its corners, its tracked edges, and
+// the edges x2 takes to the tracked ones
+func (t *optimalTables) bound(s *optimalState) int {
+	var h uint8
+	if t.corners != nil {
+		h = t.corners.get(int(s.cornerPerm)*nTwist + int(s.twist))
+	} else {
+		h = max(t.cornerPerm[s.cornerPerm], t.twist[s.twist])
+	}
+
+	k := len(t.edges)
+	var pos, mirrored [maxEdgeGroup]int8
+	flips, mirroredFlips := 0, 0
+	for i, e := range t.edges {
+		pos[i] = s.edgePos[e]
+		flips |= int(s.edgeFlip[e]) << i
+
+		// Under x2 the edge that lands on e's home plays e's part
+		m := Edge(t.edgeMirror[e])
+		mirrored[i] = t.edgeMirror[s.edgePos[m]]
+		mirroredFlips |= int(s.edgeFlip[m]) << i
+	}
+	h = max(h, t.edgeTable.get(placementIndex(pos[:k])<<k|flips))
+	h = max(h, t.edgeTable.get(placementIndex(mirrored[:k])<<k|mirroredFlips))
+	return int(h)
+}
@@ -0,0 +1,132 @@
+package cube
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// testBudget keeps the tests' pattern databases small (perm and twist
+// tables plus five edges, beside the two-phase tables) so they generate in
+// a second or two
+const testBudget = 9 << 20
+
+func TestSolveOptimal(t *testing.T) {
+	tests := []struct {
+		scramble string
+		htm, qtm int
+	}{
+		{"", 0, 0},
+		{"R", 1, 1},
+		{"R U2", 2, 3},
+		{"R U R' U'", 4, 4},
+		{"F R U' L2 D B'", 6, 7},
+	}
+	for _, metric := range []Metric{HTM, QTM} {
+		s, err := NewSolver("optimal", Options{Metric: metric, MemoryBudget: testBudget})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, tt := range tests {
+			c := NewCube()
+			c.ApplyMoves(MustParseMoves(tt.scramble))
+			solution, err := s.Solve(context.Background(), c)
+			if err != nil {
+				t.Fatalf("%s %q: %v", metric, tt.scramble, err)
+			}
+			want := tt.htm
+			if metric == QTM {
+				want = tt.qtm
+			}
+			if got := metric.Count(solution.Moves); got != want {
+				t.Errorf("%s %q: solution %s has length %d, want %d", metric, tt.scramble, FormatMoves(solution.Moves), got, want)
+			}
+		}
+	}
+}
+
+func TestSolveOptimalAnyOrientation(t *testing.T) {
+	s, _ := NewSolver("optimal", Options{MemoryBudget: testBudget})
+	c := NewCubeScheme(Japanese)
+	c.ApplyMoves(MustParseMoves("x R U' z F2"))
+	solution, err := s.Solve(context.Background(), c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(solution.Moves) != 3 {
+		t.Errorf("solution %s, want 3 moves", FormatMoves(solution.Moves))
+	}
+}
+
+func TestSolveOptimalProgressAndCancel(t *testing.T) {
+	var statuses []string
+	ctx, cancel := context.WithCancel(context.Background())
+	s, _ := NewSolver("optimal", Options{
+		MemoryBudget: testBudget,
+		Progress: func(status string) {
+			statuses = append(statuses, status)
+			if strings.HasPrefix(status, "searching depth 12") {
+				cancel()
+			}
+		},
+	})
+	c := NewCube()
+	c.ApplyMoves(MustParseMoves("D2 F' L2 U B' R2 F L' D R U2 B2 L F2 D' R' B U' L2 F R"))
+	_, err := s.Solve(ctx, c)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("got error %v, want context.Canceled", err)
+	}
+	if len(statuses) == 0 || !strings.HasPrefix(statuses[len(statuses)-1], "searching depth 12") {
+		t.Errorf("progress reported %q", statuses)
+	}
+}
+
+func TestOptimalPlan(t *testing.T) {
+	tests := []struct {
+		budget  int64
+		corners bool
+		edges   int
+	}{
+		{DefaultMemoryBudget, true, 6},
+		{testBudget, false, 5},
+		{8 << 20, false, 4},
+	}
+	for _, tt := range tests {
+		corners, edges, err := optimalPlan(tt.budget)
+		if err != nil || corners != tt.corners || edges != tt.edges {
+			t.Errorf("optimalPlan(%d) = %t, %d, %v; want %t, %d", tt.budget, corners, edges, err, tt.corners, tt.edges)
+		}
+	}
+	if _, _, err := optimalPlan(6 << 20); err == nil {
+		t.Error("expected an error for a budget the two-phase tables fill")
+	}
+
+	// The plan counts the two-phase tables as they are loaded
+	var loaded int64
+	for _, table := range loadKociembaTables().fields() {
+		loaded += int64(binary.Size(table))
+	}
+	if want := kociembaTableBytes(); loaded != want {
+		t.Errorf("two-phase tables take %d bytes, planned for %d", loaded, want)
+	}
+}
+
+func TestPlacementIndex(t *testing.T) {
+	g := edgeGroupOrder[:5]
+	n := g.size() >> len(g)
+	pos := make([]int8, len(g))
+	for idx := 0; idx < n; idx++ {
+		placementFromIndex(idx, pos)
+		if got := placementIndex(pos); got != idx {
+			t.Fatalf("placementIndex(%v) = %d, want %d", pos, got, idx)
+		}
+	}
+}
+
+func TestSolveOptimalRejectsMetric(t *testing.T) {
+	if _, err := SolveOptimal(context.Background(), NewCube(), STM); err == nil {
+		t.Error("expected an error for STM")
+	}
+}
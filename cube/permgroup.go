@@ -0,0 +1,159 @@
+package cube
+
+// Permutation groups, for telling whether a move set can reach a cube at
+// all. The pruning tables of a restricted search (see subset.go) follow one
+// group of pieces each, and every group can be in place while the whole
+// cube is not: with <R,U>, a Y-perm's corners and edges are each reachable
+// alone, but not together. The moves generate a group of permutations of
+// the facelets; a stabilizer chain built by Schreier-Sims decides whether
+// a cube is in it.
+
+// facePerm is a permutation of the 54 facelets: the 24 corner facelets, 3
+// for each position, the 24 edge facelets, 2 for each, then the 6 centers.
+// p[i] is the facelet of the solved cube that sits at i.
+type facePerm [54]uint8
+
+// facePerm returns cc as a permutation of the facelets. Multiplying
+// cubie cubes composes their permutations: a.Multiply(&b) is
+// a.facePerm().then(b.facePerm()).
+func (cc *CubieCube) facePerm() facePerm {
+	var p facePerm
+	for i := 0; i < 8; i++ {
+		for j := 0; j < 3; j++ {
+			p[3*i+j] = uint8(3*int(cc.CP[i]) + (j+3-int(cc.CO[i]))%3)
+		}
+	}
+	for i := 0; i < 12; i++ {
+		for j := 0; j < 2; j++ {
+			p[24+2*i+j] = uint8(24 + 2*int(cc.EP[i]) + (j+2-int(cc.EO[i]))%2)
+		}
+	}
+	for f, c := range cc.Centers {
+		p[48+f] = uint8(48 + int(c))
+	}
+	return p
+}
+
+// identityPerm leaves every facelet in place
+func identityPerm() facePerm {
+	var p facePerm
+	for i := range p {
+		p[i] = uint8(i)
+	}
+	return p
+}
+
+// then returns p followed by q: (p.then(q))[i] = p[q[i]]. As functions on
+// the facelet numbers, it is p after q.
+func (p facePerm) then(q facePerm) facePerm {
+	var r facePerm
+	for i := range r {
+		r[i] = p[q[i]]
+	}
+	return r
+}
+
+func (p facePerm) inverse() facePerm {
+	var r facePerm
+	for i, v := range p {
+		r[v] = uint8(i)
+	}
+	return r
+}
+
+// permLevel is one level of a stabilizer chain: the group fixing the base
+// points of the levels above, the orbit of its own base point, and for
+// each point b of the orbit a member u with u[b] == base
+type permLevel struct {
+	base  uint8
+	gens  []facePerm
+	orbit []uint8
+	to    map[uint8]facePerm
+}
+
+// permGroup is a stabilizer chain of the group some permutations generate
+type permGroup struct {
+	levels []*permLevel
+}
+
+// newPermGroup returns the group gens generate
+func newPermGroup(gens []facePerm) *permGroup {
+	g := &permGroup{}
+	for _, p := range gens {
+		if r, i := g.sift(p, 0); r != identityPerm() {
+			g.add(r, i)
+		}
+	}
+	return g
+}
+
+// contains reports whether p is in the group
+func (g *permGroup) contains(p facePerm) bool {
+	r, _ := g.sift(p, 0)
+	return r == identityPerm()
+}
+
+// sift strips p down the chain from level i, returning what is left and
+// the level it stopped at: the identity at the bottom if p is in the group
+func (g *permGroup) sift(p facePerm, i int) (facePerm, int) {
+	for ; i < len(g.levels); i++ {
+		l := g.levels[i]
+		u, ok := l.to[p[l.base]]
+		if !ok {
+			return p, i
+		}
+		p = u.then(p) // fixes the base point
+	}
+	return p, i
+}
+
+// add adds p, which fixes the base points above level i, to the group.
+// It generates part of the group each level down to i fixes, so it joins
+// the generators of level i and every level above.
+func (g *permGroup) add(p facePerm, i int) {
+	if i == len(g.levels) {
+		l := &permLevel{to: map[uint8]facePerm{}}
+		for b, v := range p {
+			if int(v) != b {
+				l.base = uint8(b)
+				break
+			}
+		}
+		l.orbit = []uint8{l.base}
+		l.to[l.base] = identityPerm()
+		g.levels = append(g.levels, l)
+	}
+	for ; i >= 0; i-- {
+		g.extend(p, i)
+	}
+}
+
+// extend adds p to level i's generators, extending its orbit. The
+// Schreier generators this makes, the permutations that fix the base
+// point, are sifted into the levels below.
+func (g *permGroup) extend(p facePerm, i int) {
+	l := g.levels[i]
+	l.gens = append(l.gens, p)
+	known := len(l.orbit)
+	for n := 0; n < len(l.orbit); n++ {
+		b := l.orbit[n]
+		gens := l.gens
+		if n < known {
+			// The old points have met the old generators already
+			gens = []facePerm{p}
+		}
+		for _, s := range gens {
+			c := s[b]
+			back := l.to[b].then(s.inverse()) // takes c to the base point
+			u, ok := l.to[c]
+			if !ok {
+				l.to[c] = back
+				l.orbit = append(l.orbit, c)
+				continue
+			}
+			if r, j := g.sift(u.then(back.inverse()), i+1); r != identityPerm() {
+				g.add(r, j)
+			}
+		}
+	}
+}
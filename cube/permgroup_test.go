@@ -0,0 +1,52 @@
+package cube
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestFacePermMultiply(t *testing.T) {
+	a, b := SolvedCubie(), SolvedCubie()
+	a.ApplyMoves(MustParseMoves("R U F' M2 x"))
+	b.ApplyMoves(MustParseMoves("D L2 B S' y"))
+	ab := a.Multiply(&b)
+	if got, want := ab.facePerm(), a.facePerm().then(b.facePerm()); got != want {
+		t.Errorf("facePerm of a product is %v, want %v", got, want)
+	}
+	if inv := a.Inverse(); inv.facePerm() != a.facePerm().inverse() {
+		t.Error("facePerm of the inverse is not the inverse")
+	}
+}
+
+func TestPermGroupOrder(t *testing.T) {
+	tests := []struct {
+		set, order string
+	}{
+		{"<R,U>", "73483200"},
+		{"<R2,U2>", "12"},
+		{"<U,D,R2,L2,F2,B2>", "19508428800"},
+		{"<U,R,F,D,L,B>", "43252003274489856000"},
+	}
+	for _, tt := range tests {
+		set, _ := ParseMoveSet(tt.set)
+		var gens []facePerm
+		for _, m := range set {
+			cc := moveCubies[m]
+			gens = append(gens, cc.facePerm())
+		}
+		g := newPermGroup(gens)
+		order := big.NewInt(1)
+		for _, l := range g.levels {
+			order.Mul(order, big.NewInt(int64(len(l.orbit))))
+		}
+		if order.String() != tt.order {
+			t.Errorf("%s: order %s, want %s", tt.set, order, tt.order)
+		}
+		for _, m := range set {
+			cc := moveCubies[m]
+			if !g.contains(cc.facePerm()) {
+				t.Errorf("%s: doesn't contain %s", tt.set, m)
+			}
+		}
+	}
+}
@@ -0,0 +1,422 @@
+package cube
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Roux method solver
+// Solves the way Roux solvers do, with white on the bottom: a 1x2x3
+// first block on the left, a second block on the right using only R, r, U
+// and M, the last layer's corners in one CMLL algorithm (see
+// roux_cases.go), then the last six edges (LSE) with M and U alone, in
+// three steps: orient them, bring the UL and UR edges home, and permute
+// the M slice. M and r are searched as moves of their own, so they appear
+// in the solution as single slice and wide turns. Each search has an exact
+// distance table, so the blocks and each LSE step take as few moves as
+// they can.
+
+// rouxMoveNames are the moves of the block searches: the 18 face turns,
+// with the same indices as moveNames, then M and Rw
+var rouxMoveNames = append(moveNames[:], "M", "M2", "M'", "Rw", "Rw2", "Rw'")
+
+// lseMoveNames are the moves of LSE
+var lseMoveNames = []string{"U", "U2", "U'", "M", "M2", "M'"}
+
+// cubiesOf returns the cubie cubes of moves
+func cubiesOf(names []string) []CubieCube {
+	cubies := make([]CubieCube, len(names))
+	for i, name := range names {
+		cubies[i] = moveCubies[Move(name)]
+	}
+	return cubies
+}
+
+// rouxBlock is a 1x2x3 block: three edges and two corners, all on one
+// side, and the moves that build it
+type rouxBlock struct {
+	edges   edgeGroup
+	corners [2]Corner
+	side    Face
+	moves   []int
+}
+
+var rouxBlocks = [2]rouxBlock{
+	// The first block may use any face turn
+	{edgeGroup{DL, FL, BL}, [2]Corner{DLF, DBL}, Left, allMoveIndices()},
+	// The second block is built with R, r, U and M, which keep the first
+	{edgeGroup{DR, FR, BR}, [2]Corner{DFR, DRB}, Right, []int{0, 1, 2, 3, 4, 5, 18, 19, 20, 21, 22, 23}},
+}
+
+// nBlockCorners is the size of a block's corner coordinate
+const nBlockCorners = 24 * 24
+
+// cornerPairCoord encodes where two corners are and how they are twisted
+// (0-575)
+func (cc *CubieCube) cornerPairCoord(c [2]Corner) int {
+	return cc.cornerCoord(c[0])*24 + cc.cornerCoord(c[1])
+}
+
+// lseEdges are the last six edges, UL and UR first
+var lseEdges = [6]Edge{UR, UL, UF, UB, DF, DB}
+
+// nLSE is the size of the LSE coordinate: permutations of the six edges,
+// the flips of five of them (the sixth follows), and how far M and U are
+// turned from home
+const nLSE = 720 * 32 * 4 * 4
+
+// lseCenters[k] are the centers after k turns of M, and lseCorners[k] the
+// corner at URF after k turns of U
+var lseCenters, lseCorners = func() (centers [4][6]Face, corners [4]Corner) {
+	cc := SolvedCubie()
+	for k := 0; k < 4; k++ {
+		centers[k], corners[k] = cc.Centers, cc.CP[URF]
+		cc.ApplyMove("M")
+		cc.ApplyMove(U)
+	}
+	return centers, corners
+}()
+
+// lseCoord encodes the last six edges, the M slice centers and the turn of
+// U of a cube whose blocks and corners are solved up to turns of M and U
+func (cc *CubieCube) lseCoord() int {
+	var perm [6]Edge
+	flips := 0
+	for i, pos := range lseEdges {
+		for j, e := range lseEdges {
+			if cc.EP[pos] == e {
+				perm[i] = Edge(j)
+			}
+		}
+		if i < 5 {
+			flips |= int(cc.EO[pos]) << i
+		}
+	}
+	centers, corners := 0, 0
+	for k := 0; k < 4; k++ {
+		if cc.Centers == lseCenters[k] {
+			centers = k
+		}
+		if cc.CP[URF] == lseCorners[k] {
+			corners = k
+		}
+	}
+	return ((permRank(perm[:])*32+flips)*4+centers)*4 + corners
+}
+
+// rouxTables are the move and pruning tables of the Roux searches. The
+// pruning tables are exact distances: to each block over its edges and
+// corners, and to the goal of each LSE step.
+type rouxTables struct {
+	edgeMove   [2][]uint16
+	cornerMove [2][]uint16
+	blockPrune [2][]uint8
+	edgeHome   [2]int
+	cornerHome [2]int
+
+	lseMove   []uint32
+	eoPrune   []uint8 // the six edges oriented
+	ulurPrune []uint8 // and UL and UR solved
+	lsePrune  []uint8 // and the cube solved
+}
+
+// rouxCacheMagic identifies the Roux table cache file layout
+const rouxCacheMagic = "roux-tables-v1"
+
+var (
+	rouxOnce  sync.Once
+	rouxTable *rouxTables
+)
+
+// loadRouxTables returns the Roux tables, generating them on first use.
+// The pruning tables and the LSE move table are cached on disk.
+func loadRouxTables() *rouxTables {
+	rouxOnce.Do(func() {
+		t := newRouxTables()
+		path := tableCachePath("roux.bin")
+		if readTableFile(path, rouxCacheMagic, t.fields()...) != nil {
+			t.generate()
+			_ = writeTableFile(path, rouxCacheMagic, t.fields()...)
+		}
+		rouxTable = t
+	})
+	return rouxTable
+}
+
+// newRouxTables makes the tables, generating the blocks' move tables; the
+// rest are allocated but left empty
+func newRouxTables() *rouxTables {
+	cubies := cubiesOf(rouxMoveNames)
+	all := make([]int, len(cubies))
+	for i := range all {
+		all[i] = i
+	}
+	solved := SolvedCubie()
+	t := &rouxTables{
+		lseMove:   make([]uint32, nLSE*len(lseMoveNames)),
+		eoPrune:   make([]uint8, nLSE),
+		ulurPrune: make([]uint8, nLSE),
+		lsePrune:  make([]uint8, nLSE),
+	}
+	for b, block := range rouxBlocks {
+		edges := func(cc *CubieCube) int { return cc.edgeGroupCoord(block.edges) }
+		corners := func(cc *CubieCube) int { return cc.cornerPairCoord(block.corners) }
+		t.edgeMove[b] = moveTableOf[uint16](block.edges.size(), edges, cubies, all)
+		t.cornerMove[b] = moveTableOf[uint16](nBlockCorners, corners, cubies, all)
+		t.edgeHome[b], t.cornerHome[b] = edges(&solved), corners(&solved)
+		t.blockPrune[b] = make([]uint8, block.edges.size()*nBlockCorners)
+	}
+	return t
+}
+
+// fields lists the cached tables in their cache file order
+func (t *rouxTables) fields() []any {
+	return []any{t.blockPrune[0], t.blockPrune[1], t.lseMove, t.eoPrune, t.ulurPrune, t.lsePrune}
+}
+
+func (t *rouxTables) generate() {
+	stride := len(rouxMoveNames)
+	for b, block := range rouxBlocks {
+		t.blockPrune[b] = pruneTableOf(stride, block.edges.size(), nBlockCorners, t.edgeMove[b], t.cornerMove[b],
+			block.moves, []int{t.edgeHome[b]*nBlockCorners + t.cornerHome[b]})
+	}
+
+	lse := make([]int, len(lseMoveNames))
+	for i := range lse {
+		lse[i] = i
+	}
+	t.lseMove = moveTableOf[uint32](nLSE, (*CubieCube).lseCoord, cubiesOf(lseMoveNames), lse)
+
+	// Edges are oriented when their U and D stickers face U or D once the
+	// centers are back on U and D, or turned over by M2. An odd turn of M
+	// flips the four M slice edges, so then they must all be flipped.
+	mc := moveCubies["M"]
+	mFlips := mc.lseCoord() / 16 % 32
+	var eo, ulur []int
+	for i := 0; i < nLSE; i++ {
+		corners, centers, flips, perm := i%4, i/4%4, i/16%32, i/512
+		if centers%2 == 0 && flips != 0 || centers%2 == 1 && flips != mFlips {
+			continue
+		}
+		eo = append(eo, i)
+		// UL and UR are the first two edges, so they are home exactly
+		// for the first 4! permutations
+		if corners == 0 && perm < 24 {
+			ulur = append(ulur, i)
+		}
+	}
+	solved := SolvedCubie()
+	none := make([]uint16, len(lse))
+	t.eoPrune = pruneTableOf(len(lse), 1, nLSE, none, t.lseMove, lse, eo)
+	t.ulurPrune = pruneTableOf(len(lse), 1, nLSE, none, t.lseMove, lse, ulur)
+	t.lsePrune = pruneTableOf(len(lse), 1, nLSE, none, t.lseMove, lse, []int{solved.lseCoord()})
+}
+
+// descend follows an exact distance table from state down to its goal,
+// taking at each step the first move that brings it one move closer
+func descend(state int, dist func(int) uint8, next func(state, m int) int, moves []int) ([]int, error) {
+	d := dist(state)
+	if d == 0xff {
+		return nil, fmt.Errorf("no moves reach the goal")
+	}
+	var path []int
+	for d > 0 {
+		found := false
+		for _, m := range moves {
+			if n := next(state, m); dist(n) == d-1 {
+				path, state, d, found = append(path, m), n, d-1, true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("distance table is inconsistent")
+		}
+	}
+	return path, nil
+}
+
+// block returns the shortest moves that build block b of cc
+func (t *rouxTables) block(cc *CubieCube, b int) ([]Move, error) {
+	stride := len(rouxMoveNames)
+	state := cc.edgeGroupCoord(rouxBlocks[b].edges)*nBlockCorners + cc.cornerPairCoord(rouxBlocks[b].corners)
+	path, err := descend(state,
+		func(s int) uint8 { return t.blockPrune[b][s] },
+		func(s, m int) int {
+			e, c := s/nBlockCorners, s%nBlockCorners
+			return int(t.edgeMove[b][e*stride+m])*nBlockCorners + int(t.cornerMove[b][c*stride+m])
+		},
+		rouxBlocks[b].moves)
+	if err != nil {
+		return nil, err
+	}
+	return indexMoves(rouxMoveNames, path), nil
+}
+
+// lse returns the shortest moves of M and U that take cc to the goal of
+// the LSE step whose distances are prune
+func (t *rouxTables) lse(cc *CubieCube, prune []uint8) ([]Move, error) {
+	stride := len(lseMoveNames)
+	moves := make([]int, stride)
+	for i := range moves {
+		moves[i] = i
+	}
+	path, err := descend(cc.lseCoord(),
+		func(s int) uint8 { return prune[s] },
+		func(s, m int) int { return int(t.lseMove[s*stride+m]) },
+		moves)
+	if err != nil {
+		return nil, err
+	}
+	return indexMoves(lseMoveNames, path), nil
+}
+
+// SolveRoux solves the cube with the Roux method, returning its stages:
+// the rotations that turn white to the bottom and the first block's side
+// to the left, the first and second blocks, CMLL and the three steps of
+// LSE. The moves after the rotations are written as the cube is then held,
+// so CMLL reads as learnt and LSE turns only M and U.
+func (c *Cube) SolveRoux() (solution []Move, stages []Stage, err error) {
+	return solveRoux(context.Background(), c, nil)
+}
+
+// rouxSolve holds the cube, held with white on the bottom, at piece level
+// as the method works through it. Turns of M leave the centers off, so the
+// stickers can no longer say where the pieces belong.
+type rouxSolve struct {
+	tables *rouxTables
+	cube   *Cube // the cube as first held, for the colors of its centers
+	cc     CubieCube
+	stages []Stage
+}
+
+func solveRoux(ctx context.Context, c *Cube, cost *CostModel) (solution []Move, stages []Stage, err error) {
+	if err := c.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("roux: %w", err)
+	}
+	hold, _ := c.holding(func(h *Cube) bool { return h.faces[Down][4] == White })
+	t := loadRouxTables()
+
+	// Build the blocks on whichever side makes them shortest, or the
+	// quickest of those
+	var best *rouxSolve
+	var view rotation
+	for k := 0; k < 4; k++ {
+		r := rotationY.times(k)
+		cube := c.held(hold).held(r)
+		cc, _ := cubieFromFacelets(cube.KociembaString())
+		s := &rouxSolve{tables: t, cube: cube, cc: cc}
+		if err := s.blocks(); err != nil {
+			return nil, nil, fmt.Errorf("roux: %w", err)
+		}
+		if best == nil || cost.shorter(s.moves(), best.moves()) {
+			best, view = s, r
+		}
+	}
+
+	s := best
+	for _, step := range []func() error{s.cmll, s.lse} {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, fmt.Errorf("roux: %w", err)
+		}
+		if err := step(); err != nil {
+			return nil, nil, fmt.Errorf("roux: %w", err)
+		}
+	}
+	stages = append([]Stage{inspection(hold.then(view), "hold the cube with the white center on the bottom and the quickest first block on the left")}, s.stages...)
+	for _, stage := range stages {
+		solution = append(solution, stage.Moves...)
+	}
+	return solution, stages, nil
+}
+
+// add applies a stage's moves, tidied up, and records the stage; skipped
+// says the cube had already reached its goal
+func (s *rouxSolve) add(name, description string, moves []Move, skipped bool) {
+	moves = Simplify(moves)
+	s.cc.ApplyMoves(moves)
+	s.stages = append(s.stages, Stage{Name: name, Description: description, Moves: moves, Skipped: skipped})
+}
+
+// moves returns the moves of the stages so far
+func (s *rouxSolve) moves() []Move {
+	var moves []Move
+	for _, stage := range s.stages {
+		moves = append(moves, stage.Moves...)
+	}
+	return moves
+}
+
+func (s *rouxSolve) blocks() error {
+	for b, name := range []string{"First block", "Second block"} {
+		moves, err := s.tables.block(&s.cc, b)
+		if err != nil {
+			return err
+		}
+		col := colorName(s.cube.faces[rouxBlocks[b].side][4])
+		block := rouxBlocks[b]
+		built := s.cc.edgeGroupCoord(block.edges) == s.tables.edgeHome[b] && s.cc.cornerPairCoord(block.corners) == s.tables.cornerHome[b]
+		s.add(name, "build a 1x2x3 block around the "+col+" center", moves, built)
+	}
+	return nil
+}
+
+func (s *rouxSolve) cmll() error {
+	pre, cmll, err := recognizeCMLL(&s.cc)
+	if err != nil {
+		return err
+	}
+	if cmll < 0 {
+		s.add("CMLL skip", "the last layer's corners are already solved", nil, true)
+		return nil
+	}
+	moves := append(append([]Move(nil), auf[pre]...), MustParseMoves(cmllCases[cmll].alg)...)
+	s.add("CMLL "+cmllCases[cmll].name, "solve the last layer's corners, keeping both blocks", moves, false)
+	return nil
+}
+
+func (s *rouxSolve) lse() error {
+	left, right := colorName(s.cube.faces[Left][4]), colorName(s.cube.faces[Right][4])
+	steps := []struct {
+		name, description string
+		prune             []uint8
+	}{
+		{"LSE EO", "orient the last six edges", s.tables.eoPrune},
+		{"LSE UL/UR", "solve the last layer's " + left + " and " + right + " edges", s.tables.ulurPrune},
+		{"LSE EP", "permute the M slice edges and centers", s.tables.lsePrune},
+	}
+	for _, step := range steps {
+		moves, err := s.tables.lse(&s.cc, step.prune)
+		if err != nil {
+			return err
+		}
+		s.add(step.name, step.description, moves, step.prune[s.cc.lseCoord()] == 0)
+	}
+	return nil
+}
+
+// rouxSolver is the registry adapter for SolveRoux
+type rouxSolver struct {
+	cost *CostModel
+}
+
+func init() {
+	Register("roux", func(opts Options) Solver { return rouxSolver{cost: opts.Cost} })
+}
+
+func (rouxSolver) Name() string { return "roux" }
+
+func (rouxSolver) Capabilities() Capabilities {
+	return Capabilities{HumanReadable: true, StepAnnotations: true}
+}
+
+func (s rouxSolver) Solve(ctx context.Context, c *Cube) (Solution, error) {
+	moves, stages, err := solveRoux(ctx, c, s.cost)
+	if err != nil {
+		return Solution{}, err
+	}
+	if err := checkSolution(c, moves); err != nil {
+		return Solution{}, err
+	}
+	return Solution{Solver: s.Name(), Moves: moves, Stages: stages}, nil
+}
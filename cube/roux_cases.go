@@ -0,0 +1,106 @@
+package cube
+
+import "fmt"
+
+// The CMLL cases of the Roux method: the 42 ways the last four corners can
+// sit once both blocks are built, up to turns of U. CMLL solves them in one
+// algorithm while keeping the blocks, and is free to disturb the six edges
+// and the M slice centers, which LSE solves afterwards. Like the CFOP cases
+// each one is recognised by the pattern its algorithm solves.
+//
+// Cases are named after their corner orientation, in the usual sets (O, H,
+// Pi, U, T, S for Sune, AS for Anti-Sune and L), and the two corners the
+// case swaps when held to be oriented the way that set's OCLL algorithm
+// expects: front, left, back or right, diagonal, or none.
+
+var cmllCases = []llCase{
+	{"O adjacent swap", "x R' U R' D2 R U' R' D2 R2 x'"},
+	{"O diagonal swap", "R' U R' U' R D' R' D R' U D' R2 U' R2 D R2"},
+	{"H no swap", "R U2 R' U' R U R' U' R U' R'"},
+	{"H diagonal swap", "F R U R' U' R U R' U' R U R' U' F'"},
+	{"H back swap", "Rw U' Rw2 D' Rw U' Rw' D Rw2 U Rw'"},
+	{"H left swap", "R U2 R2 F R F' U2 R' F R F'"},
+	{"Pi no swap", "R U2 R2 U' R2 U' R2 U2 R"},
+	{"Pi diagonal swap", "R U R' U R U' B U' B' R'"},
+	{"Pi front swap", "R U2 R' U' R U R' U2 R' F R F'"},
+	{"Pi left swap", "Rw U' Rw2 D' Rw U Rw' D Rw2 U Rw'"},
+	{"Pi back swap", "F R' F' R U2 R U' R' U R U2 R'"},
+	{"Pi right swap", "R' F R U F U' R U R' U' F'"},
+	{"U no swap", "R2 F U' F U F2 R2 U' R' F R"},
+	{"U diagonal swap", "F' U' L' U L F"},
+	{"U front swap", "Rw U' Rw' U Rw' D' Rw U' Rw' D Rw"},
+	{"U left swap", "R2 D' R U2 R' D R U2 R"},
+	{"U back swap", "F R2 D R' U R D' R2 U' F'"},
+	{"U right swap", "R2 D R' U2 R D' R' U2 R'"},
+	{"T no swap", "F R' F R2 U' R' U' R U R' F2"},
+	{"T diagonal swap", "Rw2 D' Rw U Rw' D Rw2 U' Rw' U' Rw"},
+	{"T front swap", "R U R' U' R' F R F'"},
+	{"T left swap", "Rw' U Rw U2 R2 F R F' R"},
+	{"T back swap", "L' U' L U L F' L' F"},
+	{"T right swap", "Rw' D' Rw U Rw' D Rw U' Rw U Rw'"},
+	{"S no swap", "R U R' U R U2 R'"},
+	{"S diagonal swap", "R U R' U R' F R F' R U2 R'"},
+	{"S front swap", "R U R' U R U' R D R' U' R D' R2"},
+	{"S left swap", "R U' L' U R' U' L"},
+	{"S back swap", "L' U2 L U2 L F' L' F"},
+	{"S right swap", "F R' F' R U2 R U2 R'"},
+	{"AS no swap", "R' U' R U' R' U2 R"},
+	{"AS diagonal swap", "R U2 R' F R' F' R U' R U' R'"},
+	{"AS front swap", "F' L F L' U2 L' U2 L"},
+	{"AS left swap", "R U2 R' U2 R' F R F'"},
+	{"AS back swap", "R' U L U' R U L'"},
+	{"AS right swap", "R2 D R' U R D' R' U R' U' R U' R'"},
+	{"L no swap", "R U2 R' U' R U R' U' R U R' U' R U' R'"},
+	{"L diagonal swap", "R U2 R2 F R F' R U2 R'"},
+	{"L front swap", "F R' F' R U R U' R'"},
+	{"L left swap", "F' Rw U R' U' Rw' F R"},
+	{"L back swap", "R' U2 R' D' R U2 R' D R2"},
+	{"L right swap", "R U2 R D R' U2 R D' R2"},
+}
+
+// cmllPattern is where the last layer's corners are and how they are
+// twisted
+type cmllPattern [8]int8
+
+func cmllPatternOf(cc *CubieCube) cmllPattern {
+	var p cmllPattern
+	for i := URF; i <= UBR; i++ {
+		p[i] = int8(cc.CP[i])
+		p[4+int(i)] = cc.CO[i]
+	}
+	return p
+}
+
+// cmllTable maps the pattern each CMLL algorithm, followed by each turn of
+// U, solves to its case. The turn itself is left to LSE.
+var cmllTable = func() map[cmllPattern]int {
+	table := make(map[cmllPattern]int, 4*len(cmllCases))
+	for i, c := range cmllCases {
+		for a := 0; a < 4; a++ {
+			cc := SolvedCubie()
+			cc.ApplyMoves(Invert(auf[a]))
+			cc.ApplyMoves(Invert(MustParseMoves(c.alg)))
+			table[cmllPatternOf(&cc)] = i
+		}
+	}
+	return table
+}()
+
+// recognizeCMLL returns the turn of U and the CMLL case that solve the
+// last layer's corners of cc up to a turn of U, or -1 for the case if they
+// already are
+func recognizeCMLL(cc *CubieCube) (pre, cmll int, err error) {
+	solved := SolvedCubie()
+	for a := 0; a < 4; a++ {
+		v := *cc
+		v.ApplyMoves(auf[a])
+		p := cmllPatternOf(&v)
+		if p == cmllPatternOf(&solved) {
+			return 0, -1, nil
+		}
+		if i, ok := cmllTable[p]; ok {
+			return a, i, nil
+		}
+	}
+	return 0, 0, fmt.Errorf("no CMLL case matches %v", cmllPatternOf(cc))
+}
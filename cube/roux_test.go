@@ -0,0 +1,211 @@
+package cube
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// rouxBlocksSolved reports whether the cube, held some way, has the given
+// Roux blocks built, and returns it held that way. The blocks are only
+// relative to the L and R centers, since turns of M leave the other
+// centers off.
+func rouxBlocksSolved(cc *CubieCube, blocks ...int) (CubieCube, bool) {
+	for _, r := range rotationCubies {
+		v := r.Inverse()
+		v = v.Multiply(cc)
+		v = v.Multiply(&r)
+		ok := v.Centers[Left] == Left && v.Centers[Right] == Right
+		for _, b := range blocks {
+			block := rouxBlocks[b]
+			for _, c := range block.corners {
+				ok = ok && v.CP[c] == c && v.CO[c] == 0
+			}
+			for _, e := range block.edges {
+				ok = ok && v.EP[e] == e && v.EO[e] == 0
+			}
+		}
+		if ok {
+			return v, true
+		}
+	}
+	return CubieCube{}, false
+}
+
+func TestSolveRoux(t *testing.T) {
+	s := NewScrambler(1)
+	for i := 0; i < 30; i++ {
+		scramble := s.RandomMoves(25)
+		c := NewCube()
+		c.ApplyMoves(scramble)
+
+		solution, stages, err := c.SolveRoux()
+		if err != nil {
+			t.Fatalf("%s: %v", FormatMoves(scramble), err)
+		}
+		var names []string
+		for _, stage := range stages {
+			names = append(names, strings.Fields(stage.Name)[0])
+		}
+		if got := strings.Join(names, " "); got != "Inspection First Second CMLL LSE LSE LSE" {
+			t.Fatalf("%s: stages %s", FormatMoves(scramble), got)
+		}
+
+		// Follow the stages at piece level, where turns of M are tracked,
+		// from the cube as the rotations leave it held
+		held := c.Clone()
+		held.ApplyMoves(stages[0].Moves)
+		cc, err := cubieFromFacelets(held.KociembaString())
+		if err != nil {
+			t.Fatal(err)
+		}
+		cc.ApplyMoves(stages[1].Moves)
+		if _, ok := rouxBlocksSolved(&cc, 0); !ok {
+			t.Fatalf("%s: first block %s left it unsolved", FormatMoves(scramble), FormatMoves(stages[1].Moves))
+		}
+		cc.ApplyMoves(stages[2].Moves)
+		if _, ok := rouxBlocksSolved(&cc, 0, 1); !ok {
+			t.Fatalf("%s: second block %s left it unsolved", FormatMoves(scramble), FormatMoves(stages[2].Moves))
+		}
+		cc.ApplyMoves(stages[3].Moves)
+		v, ok := rouxBlocksSolved(&cc, 0, 1)
+		if !ok {
+			t.Fatalf("%s: %s broke the blocks", FormatMoves(scramble), stages[3].Name)
+		}
+		if _, cmll, _ := recognizeCMLL(&v); cmll != -1 {
+			t.Fatalf("%s: %s left the corners unsolved", FormatMoves(scramble), stages[3].Name)
+		}
+		// Held as the rotations leave it, LSE turns only M and U
+		for _, stage := range stages[4:] {
+			for _, m := range stage.Moves {
+				if base, _ := splitMove(m); base != "M" && base != "U" {
+					t.Fatalf("%s: %s uses %s", FormatMoves(scramble), stage.Name, m)
+				}
+			}
+		}
+
+		c.ApplyMoves(solution)
+		if !c.IsSolved() {
+			t.Fatalf("%s: solution %s left %s", FormatMoves(scramble), FormatMoves(solution), c.KociembaString())
+		}
+	}
+}
+
+func TestSolveRouxAnyOrientation(t *testing.T) {
+	c := NewCubeScheme(Japanese)
+	c.ApplyMoves(MustParseMoves("x R U' z F2 D y B L2 U M"))
+	solution, _, err := c.SolveRoux()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.ApplyMoves(solution)
+	if !c.IsSolved() {
+		t.Fatalf("solution %s left %s", FormatMoves(solution), c.KociembaString())
+	}
+}
+
+func TestSolveRouxLastSixEdges(t *testing.T) {
+	// White on the bottom with only the last six edges scrambled, and the
+	// centers back home: the blocks and corners are done, and LSE is M and
+	// U alone
+	c := NewCube()
+	c.ApplyMoves(MustParseMoves("x2 M U M' U2 M' U' M U2"))
+	_, stages, err := c.SolveRoux()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, stage := range stages[:4] {
+		if len(stage.Moves) != 0 {
+			t.Errorf("%s: %s, want no moves", stage.Name, FormatMoves(stage.Moves))
+		}
+	}
+	if stages[3].Name != "CMLL skip" {
+		t.Errorf("got %q, want CMLL skip", stages[3].Name)
+	}
+	for _, stage := range stages[4:] {
+		for _, m := range stage.Moves {
+			if base, _ := splitMove(m); base != "M" && base != "U" {
+				t.Errorf("%s uses %s", stage.Name, m)
+			}
+		}
+	}
+
+	// With a Sune to do first, and LSE steps that meet with turns of U,
+	// the stages read the same through a chain, as users get them
+	c = NewCube()
+	c.ApplyMoves(MustParseMoves("x2 M U M' U2 M' U' M U2"))
+	c.ApplyMoves(Invert(MustParseMoves(cmllCases[24].alg)))
+	_, own, err := c.SolveRoux()
+	if err != nil {
+		t.Fatal(err)
+	}
+	chain, err := NewChain([]string{"roux"}, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	solution, err := chain.Solve(context.Background(), c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := solution.Stages[3]; got.Name != "CMLL S no swap" || FormatMoves(got.Moves) != cmllCases[24].alg {
+		t.Errorf("got %s: %s, want CMLL S no swap: %s", got.Name, FormatMoves(got.Moves), cmllCases[24].alg)
+	}
+	for i, stage := range solution.Stages {
+		if got, want := FormatMoves(stage.Moves), FormatMoves(own[i].Moves); got != want {
+			t.Errorf("%s: got %s through the chain, want %s", stage.Name, got, want)
+		}
+	}
+}
+
+func TestCMLLAlgorithms(t *testing.T) {
+	names := map[string]bool{}
+	for _, c := range cmllCases {
+		if names[c.name] {
+			t.Errorf("CMLL %s is named twice", c.name)
+		}
+		names[c.name] = true
+		cc := SolvedCubie()
+		cc.ApplyMoves(MustParseMoves(c.alg))
+		if _, ok := rouxBlocksSolved(&cc, 0, 1); !ok {
+			t.Errorf("CMLL %s: %s disturbs the blocks", c.name, c.alg)
+		}
+	}
+	if len(cmllTable) != 4*len(cmllCases) {
+		t.Errorf("%d CMLL cases share patterns", len(cmllCases)-len(cmllTable)/4)
+	}
+}
+
+func TestRecognizeEveryCMLL(t *testing.T) {
+	// Every arrangement of the last layer's corners is one CMLL case, up to
+	// turns of U
+	seen := map[int]bool{}
+	for _, cp := range permutations(4) {
+		for co := 0; co < 81; co++ {
+			cc := SolvedCubie()
+			twist := 0
+			for i := 0; i < 4; i++ {
+				cc.CP[i] = Corner(cp[i])
+				cc.CO[i] = int8(co / pow(3, i) % 3)
+				twist += int(cc.CO[i])
+			}
+			if twist%3 != 0 {
+				continue
+			}
+			pre, cmll, err := recognizeCMLL(&cc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			seen[cmll] = true
+			cc.ApplyMoves(auf[pre])
+			if cmll >= 0 {
+				cc.ApplyMoves(MustParseMoves(cmllCases[cmll].alg))
+			}
+			if _, again, _ := recognizeCMLL(&cc); again != -1 {
+				t.Fatalf("CMLL %s did not solve the corners", cmllCases[cmll].name)
+			}
+		}
+	}
+	if len(seen) != len(cmllCases)+1 {
+		t.Errorf("recognised %d CMLL cases, want %d and the solved case", len(seen)-1, len(cmllCases))
+	}
+}
@@ -0,0 +1,123 @@
+package cube
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ColorScheme gives the color of each face of a solved cube, indexed by
+// Face. Which color is where on a cube is fixed by its manufacturer; solvers
+// don't depend on it, since they read faces by their centers, but turning
+// stickers back into pieces (see Cube.Cubie) does.
+type ColorScheme [6]Color
+
+// Common color schemes
+var (
+	// Western is the scheme of most cubes: white opposite yellow, green
+	// opposite blue and red opposite orange
+	Western = ColorScheme{Green, Red, Blue, Orange, White, Yellow}
+	// Japanese swaps blue and yellow, so white is opposite blue
+	Japanese = ColorScheme{Green, Red, Yellow, Orange, White, Blue}
+)
+
+// String spells the scheme as color letters in Face order, e.g. "GRBOWY"
+func (s ColorScheme) String() string {
+	var b strings.Builder
+	for _, col := range s {
+		b.WriteString(col.String())
+	}
+	return b.String()
+}
+
+// ParseColorScheme reads a scheme by name ("western" or "japanese") or as
+// six color letters for the Front, Right, Back, Left, Up and Down faces,
+// e.g. "GRBOWY"
+func ParseColorScheme(s string) (ColorScheme, error) {
+	switch strings.ToLower(s) {
+	case "western":
+		return Western, nil
+	case "japanese":
+		return Japanese, nil
+	}
+	var scheme ColorScheme
+	if len(s) != 6 {
+		return scheme, fmt.Errorf("unknown color scheme %q (want western, japanese or six colors such as GRBOWY)", s)
+	}
+	for f := range scheme {
+		i := strings.IndexByte("WRBOGY", strings.ToUpper(s)[f])
+		if i < 0 {
+			return scheme, fmt.Errorf("color scheme %q: unknown color %q", s, s[f])
+		}
+		scheme[f] = Color(i)
+	}
+	return scheme, scheme.Validate()
+}
+
+// Validate checks that the scheme gives every face a different color
+func (s ColorScheme) Validate() error {
+	for f, col := range s {
+		if col < White || col > Yellow {
+			return fmt.Errorf("color scheme %s: %s has unknown color %d", s, Face(f), int(col))
+		}
+		if g, _ := s.face(col); g != Face(f) {
+			return fmt.Errorf("color scheme %s: %s is on both %s and %s", s, colorName(col), g, Face(f))
+		}
+	}
+	return nil
+}
+
+// face returns the face a color belongs to in the scheme
+func (s ColorScheme) face(col Color) (Face, bool) {
+	for f, fc := range s {
+		if fc == col {
+			return Face(f), true
+		}
+	}
+	return 0, false
+}
+
+// holds lists the 24 ways of holding the cube as the rotations that turn
+// it there: one of six faces on top, then one of four in front
+var holds = func() [][]Move {
+	var out [][]Move
+	for _, up := range []string{"", "x", "x2", "x'", "z", "z'"} {
+		for _, y := range []string{"", "y", "y2", "y'"} {
+			out = append(out, MustParseMoves(up+" "+y))
+		}
+	}
+	return out
+}()
+
+// holdMoves returns the rotations from holds that turn the cube by r
+func holdMoves(r rotation) []Move {
+	want := NewCube().held(r).centers()
+	for _, moves := range holds {
+		c := NewCube()
+		c.ApplyMoves(moves)
+		if c.centers() == want {
+			return moves
+		}
+	}
+	panic("cube: no rotations for a hold")
+}
+
+// inspection is the stage that turns the cube by r, to how a method holds
+// it, so the rest of the solution reads in that frame
+func inspection(r rotation, description string) Stage {
+	return Stage{Name: "Inspection", Description: description, Moves: holdMoves(r), Skipped: r == identityRotation}
+}
+
+// Orient turns the whole cube so the center colored top is on top and the
+// one colored front faces the viewer, and returns the rotations it used
+// (none if the cube is already held that way)
+func (c *Cube) Orient(top, front Color) ([]Move, error) {
+	for _, moves := range holds {
+		h := c.Clone()
+		h.ApplyMoves(moves)
+		if h.faces[Up][4] == top && h.faces[Front][4] == front {
+			*c = *h
+			return moves, nil
+		}
+	}
+	return nil, fmt.Errorf("can't hold the cube with %s on top and %s in front", colorName(top), colorName(front))
+}
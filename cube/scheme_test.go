@@ -0,0 +1,117 @@
+package cube
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseColorScheme(t *testing.T) {
+	tests := []struct {
+		input string
+		want  ColorScheme
+	}{
+		{"western", Western},
+		{"Japanese", Japanese},
+		{"GRBOWY", Western},
+		{"wgyboR", ColorScheme{White, Green, Yellow, Blue, Orange, Red}},
+	}
+	for _, tt := range tests {
+		got, err := ParseColorScheme(tt.input)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseColorScheme(%q) = %s, %v, want %s", tt.input, got, err, tt.want)
+		}
+	}
+	for _, bad := range []string{"eastern", "GRBOW", "GRBOWX", "GRBOWW"} {
+		if _, err := ParseColorScheme(bad); err == nil {
+			t.Errorf("ParseColorScheme(%q) accepted a bad scheme", bad)
+		}
+	}
+}
+
+func TestOrient(t *testing.T) {
+	c := NewCube()
+	moves, err := c.Orient(Yellow, Red)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Sticker(Up, 4) != Yellow || c.Sticker(Front, 4) != Red {
+		t.Fatalf("after %s the cube has %s on top and %s in front", FormatMoves(moves), c.Sticker(Up, 4), c.Sticker(Front, 4))
+	}
+	if !c.IsSolved() {
+		t.Fatal("orienting the cube unsolved it")
+	}
+	if moves, err := c.Orient(Yellow, Red); err != nil || len(moves) != 0 {
+		t.Errorf("Orient on an oriented cube = %s, %v, want no moves", FormatMoves(moves), err)
+	}
+	if _, err := c.Orient(White, Yellow); err == nil {
+		t.Error("Orient accepted opposite colors")
+	}
+}
+
+func TestKociembaStringFollowsCenters(t *testing.T) {
+	want := "UUUUUUUUURRRRRRRRRFFFFFFFFFDDDDDDDDDLLLLLLLLLBBBBBBBBB"
+	c := NewCubeScheme(Japanese)
+	c.ApplyMoves(MustParseMoves("x y'"))
+	if got := c.KociembaString(); got != want {
+		t.Errorf("rotated Japanese cube = %s, want %s", got, want)
+	}
+}
+
+func TestCubieInOtherScheme(t *testing.T) {
+	moves := MustParseMoves("R U F' x M2 D")
+	c := NewCubeScheme(Japanese)
+	c.ApplyMoves(moves)
+
+	cc, err := c.Cubie()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := SolvedCubie()
+	want.ApplyMoves(moves)
+	if cc != want {
+		t.Fatalf("Cubie() = %+v, want %+v", cc, want)
+	}
+	if got := cc.Paint(Japanese); got.faces != c.faces {
+		t.Fatalf("Paint(Japanese) = %s, want %s", got.KociembaString(), c.KociembaString())
+	}
+
+	// Read in the wrong scheme, some corners are mirror images
+	w := NewCube()
+	w.faces = c.faces
+	if _, err := w.Cubie(); err == nil {
+		t.Fatal("Japanese stickers read as a Western cube")
+	}
+}
+
+func TestRotations(t *testing.T) {
+	if len(rotations) != 24 || len(holds) != 24 {
+		t.Fatalf("%d rotations and %d holds, want 24", len(rotations), len(holds))
+	}
+	var seen []ColorScheme
+	for _, moves := range holds {
+		c := NewCube()
+		c.ApplyMoves(moves)
+		if slices.Contains(seen, c.centers()) {
+			t.Fatalf("%s holds the cube the same way as another rotation", FormatMoves(moves))
+		}
+		seen = append(seen, c.centers())
+	}
+}
+
+func TestUnheld(t *testing.T) {
+	// A move made holding the cube turned by r is the unheld move made
+	// holding it as it is
+	scramble := MustParseMoves("R U F' L2 D B")
+	for _, r := range rotations {
+		for m := range moveTable {
+			c := NewCube()
+			c.ApplyMoves(scramble)
+			h := c.held(r)
+			h.ApplyMove(m)
+			c.ApplyMove(r.unheld(m))
+			if c.held(r).faces != h.faces {
+				t.Fatalf("%s held by %v is not %s", m, r, r.unheld(m))
+			}
+		}
+	}
+}
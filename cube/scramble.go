@@ -0,0 +1,99 @@
+package cube
+
+import (
+	"context"
+	"math/rand/v2"
+)
+
+// Scrambler generates scrambles from a seeded random source, so the same
+// seed always gives the same sequence of scrambles
+type Scrambler struct {
+	rng *rand.Rand
+}
+
+// NewScrambler creates a scrambler seeded with seed
+func NewScrambler(seed uint64) *Scrambler {
+	return &Scrambler{rng: rand.New(rand.NewPCG(seed, seed^0x9e3779b97f4a7c15))}
+}
+
+// RandomState returns a WCA-style scramble: a uniformly random solvable
+// cube state is drawn and solved with Kociemba's algorithm, and the
+// scramble is that solution reversed (typically 20-21 face turns).
+func (s *Scrambler) RandomState() ([]Move, error) {
+	return s.RandomStateContext(context.Background())
+}
+
+// RandomStateContext is RandomState with a context bounding the solve
+func (s *Scrambler) RandomStateContext(ctx context.Context) ([]Move, error) {
+	cc := s.randomCubie()
+	solution, err := solveKociemba(ctx, cc.Cube())
+	if err != nil {
+		return nil, err
+	}
+	return Invert(solution), nil
+}
+
+// randomCubie draws a uniformly random solvable cubie cube
+func (s *Scrambler) randomCubie() CubieCube {
+	cc := SolvedCubie()
+	for i, p := range s.rng.Perm(8) {
+		cc.CP[i] = Corner(p)
+	}
+	for i, p := range s.rng.Perm(12) {
+		cc.EP[i] = Edge(p)
+	}
+	// Only states whose corner and edge permutations have the same parity
+	// are reachable. Swapping two edges pairs the others up one to one with
+	// them, so the result stays uniform.
+	if permParity(cc.CP[:]) != permParity(cc.EP[:]) {
+		cc.EP[0], cc.EP[1] = cc.EP[1], cc.EP[0]
+	}
+
+	// The last corner's twist and last edge's flip are fixed by the others
+	twist, flip := 0, 0
+	for i := 0; i < 7; i++ {
+		cc.CO[i] = int8(s.rng.IntN(3))
+		twist += int(cc.CO[i])
+	}
+	cc.CO[7] = int8((3 - twist%3) % 3)
+	for i := 0; i < 11; i++ {
+		cc.EO[i] = int8(s.rng.IntN(2))
+		flip += int(cc.EO[i])
+	}
+	cc.EO[11] = int8(flip % 2)
+	return cc
+}
+
+// RandomMoves returns n random face turns. No face is turned twice in a
+// row, and a face is not turned again straight after its opposite face (as
+// in R L R), since either would just be a shorter scramble in disguise.
+func (s *Scrambler) RandomMoves(n int) []Move {
+	const faces = "URFDLB" // opposite faces are three apart
+	var moves []Move
+	last, beforeLast := -1, -1
+	for turns := 0; turns < n; turns++ {
+		var face int
+		for {
+			face = s.rng.IntN(6)
+			if face == last {
+				continue
+			}
+			if last >= 0 && (face+3)%6 == last && face == beforeLast {
+				continue
+			}
+			break
+		}
+		beforeLast, last = last, face
+
+		m := Move(faces[face : face+1])
+		switch s.rng.IntN(3) {
+		case 0:
+			moves = append(moves, m)
+		case 1:
+			moves = append(moves, m+"2")
+		case 2:
+			moves = append(moves, m.Inverse())
+		}
+	}
+	return moves
+}
@@ -0,0 +1,73 @@
+package cube
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRandomStateScramble(t *testing.T) {
+	s := NewScrambler(42)
+	seen := map[string]bool{}
+	for i := 0; i < 5; i++ {
+		scramble, err := s.RandomState()
+		if err != nil {
+			t.Fatal(err)
+		}
+		c := NewCube()
+		c.ApplyMoves(scramble)
+		if err := c.Validate(); err != nil {
+			t.Fatalf("%s: %v", FormatMoves(scramble), err)
+		}
+		if c.IsSolved() {
+			t.Fatalf("%s leaves the cube solved", FormatMoves(scramble))
+		}
+		if seen[c.KociembaString()] {
+			t.Fatalf("%s repeats an earlier state", FormatMoves(scramble))
+		}
+		seen[c.KociembaString()] = true
+	}
+}
+
+func TestScramblerSeed(t *testing.T) {
+	a, err := NewScrambler(7).RandomState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := NewScrambler(7).RandomState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(a, b) {
+		t.Fatalf("same seed gave %s and %s", FormatMoves(a), FormatMoves(b))
+	}
+
+	if !slices.Equal(NewScrambler(7).RandomMoves(25), NewScrambler(7).RandomMoves(25)) {
+		t.Fatal("same seed gave different random-move scrambles")
+	}
+	if slices.Equal(NewScrambler(7).RandomMoves(25), NewScrambler(8).RandomMoves(25)) {
+		t.Fatal("different seeds gave the same random-move scramble")
+	}
+}
+
+func TestRandomMovesAvoidsRedundantTurns(t *testing.T) {
+	opposite := map[Face]Face{Up: Down, Down: Up, Right: Left, Left: Right, Front: Back, Back: Front}
+	s := NewScrambler(1)
+	for i := 0; i < 100; i++ {
+		moves := s.RandomMoves(25)
+		if len(moves) != 25 {
+			t.Fatalf("%s has %d face turns, want 25", FormatMoves(moves), len(moves))
+		}
+		faces := make([]Face, len(moves))
+		for j, m := range moves {
+			faces[j] = moveFace(m)
+		}
+		for j := 1; j < len(faces); j++ {
+			if faces[j] == faces[j-1] {
+				t.Fatalf("%s turns %s twice in a row", FormatMoves(moves), faces[j])
+			}
+			if j > 1 && faces[j] == faces[j-2] && faces[j-1] == opposite[faces[j]] {
+				t.Fatalf("%s turns %s either side of its opposite face", FormatMoves(moves), faces[j])
+			}
+		}
+	}
+}
@@ -0,0 +1,258 @@
+package cube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Capabilities describes the kind of solutions a solver produces
+type Capabilities struct {
+	Optimal         bool // solutions are provably the shortest possible
+	HumanReadable   bool // solutions follow a method a person can learn
+	StepAnnotations bool // solutions are broken into labelled stages
+	MoveSets        bool // solutions can be restricted to Options.Moves
+}
+
+// Stage is one labelled part of a solution, e.g. "White cross"
+type Stage struct {
+	Name        string
+	Description string // optional explanation of the stage for learners
+	Moves       []Move
+	Skipped     bool // the cube had already reached the stage's goal
+}
+
+// Solution is the result of a successful solve
+type Solution struct {
+	Solver string  // name of the solver that produced the solution
+	Moves  []Move  // the full move sequence
+	Stages []Stage // optional breakdown of Moves, in order
+	// Alternatives are further solutions, longest last, when
+	// Options.MaxSolutions asks for more than one
+	Alternatives [][]Move
+}
+
+// Solver finds a move sequence that solves a cube
+type Solver interface {
+	Name() string
+	Capabilities() Capabilities
+	// Solve returns a solution for c without modifying it. When it
+	// can't, the error says why.
+	Solve(ctx context.Context, c *Cube) (Solution, error)
+}
+
+// Options configure the solvers created through the registry. Solvers
+// ignore the options that don't apply to them.
+type Options struct {
+	// History lists the moves applied since the cube was last solved; the
+	// reversal solver undoes them.
+	History []Move
+	// Metric is what the optimal solver minimises: HTM (the default) or
+	// QTM.
+	Metric Metric
+	// MemoryBudget caps the bytes the optimal solver spends on its tables,
+	// the two-phase tables it shares included; 0 means
+	// DefaultMemoryBudget. Bigger tables search faster.
+	MemoryBudget int64
+	// Moves, if set, restricts the search-based solvers (kociemba, optimal
+	// and thistlethwaite) to these moves, e.g. from ParseMoveSet("<R,U>").
+	// They then search within the moves alone (see subset.go), counting
+	// each move as one, and fail with ErrUnreachable if the cube can't be
+	// solved that way. NewSolver refuses solvers that can't restrict
+	// their moves (see Capabilities.MoveSets).
+	Moves []Move
+	// MaxDepth bounds the length of restricted solutions; 0 means 20.
+	MaxDepth int
+	// MaxSolutions is how many restricted solutions to find, shortest
+	// first; 0 means 1 and a negative number every one within MaxDepth.
+	// All but the first are returned as Solution.Alternatives.
+	MaxSolutions int
+	// Cost, if set, breaks ties between equally short solutions in favour
+	// of the quicker to perform (see CostModel): among restricted
+	// solutions, the F2L pair orders of cfop and zz, and the sides roux and
+	// zz start from.
+	Cost *CostModel
+	// Progress, if set, receives status updates from long-running solvers,
+	// e.g. "searching depth 17…". It is called from the solving goroutine.
+	Progress func(status string)
+}
+
+// Factory creates a solver configured by opts
+type Factory func(opts Options) Solver
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Factory{}
+)
+
+// DefaultChain is the solver chain used when the caller doesn't choose one
+var DefaultChain = []string{"kociemba", "reversal"}
+
+// Register makes a solver available by name. Registering a name twice
+// replaces the earlier factory.
+func Register(name string, factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[name] = factory
+}
+
+// Solvers returns the names of all registered solvers, sorted
+func Solvers() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewSolver creates the registered solver called name
+func NewSolver(name string, opts Options) (Solver, error) {
+	registryMu.RLock()
+	factory, ok := registry[name]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown solver %q (available: %s)", name, strings.Join(Solvers(), ", "))
+	}
+	s := factory(opts)
+	if len(opts.Moves) > 0 && !s.Capabilities().MoveSets {
+		return nil, fmt.Errorf("%s can't restrict its moves to %s", name, FormatMoveSet(opts.Moves))
+	}
+	return s, nil
+}
+
+// NewChain creates a Chain of the named solvers
+func NewChain(names []string, opts Options) (Chain, error) {
+	if len(names) == 0 {
+		return nil, errors.New("empty solver chain")
+	}
+	chain := make(Chain, 0, len(names))
+	for _, name := range names {
+		s, err := NewSolver(name, opts)
+		if err != nil {
+			return nil, err
+		}
+		chain = append(chain, s)
+	}
+	return chain, nil
+}
+
+// Chain is a Solver that tries each of its solvers in turn and returns the
+// first solution found
+type Chain []Solver
+
+// Name lists the chained solvers, e.g. "kociemba,reversal"
+func (ch Chain) Name() string {
+	names := make([]string, len(ch))
+	for i, s := range ch {
+		names[i] = s.Name()
+	}
+	return strings.Join(names, ",")
+}
+
+// Capabilities are those shared by every solver in the chain, since any of
+// them may end up producing the solution
+func (ch Chain) Capabilities() Capabilities {
+	if len(ch) == 0 {
+		return Capabilities{}
+	}
+	caps := Capabilities{Optimal: true, HumanReadable: true, StepAnnotations: true, MoveSets: true}
+	for _, s := range ch {
+		c := s.Capabilities()
+		caps.Optimal = caps.Optimal && c.Optimal
+		caps.HumanReadable = caps.HumanReadable && c.HumanReadable
+		caps.StepAnnotations = caps.StepAnnotations && c.StepAnnotations
+		caps.MoveSets = caps.MoveSets && c.MoveSets
+	}
+	return caps
+}
+
+// Solve returns the first solution found by the chain, with cancelling
+// moves tidied up: within each stage, so the stages keep the algorithms
+// their method teaches, or through the whole solution if it has none. If
+// every solver fails, the error lists each solver's reason.
+func (ch Chain) Solve(ctx context.Context, c *Cube) (Solution, error) {
+	var errs []error
+	for _, s := range ch {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+		solution, err := s.Solve(ctx, c)
+		if err == nil {
+			return solution.simplified(), nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", s.Name(), err))
+	}
+	if len(errs) == 0 {
+		return Solution{}, errors.New("empty solver chain")
+	}
+	return Solution{}, errors.Join(errs...)
+}
+
+// simplified returns the solution with each stage's moves simplified, and
+// the moves made up of them, or if it has no stages its moves simplified
+func (s Solution) simplified() Solution {
+	if len(s.Stages) == 0 {
+		s.Moves = Simplify(s.Moves)
+		return s
+	}
+	s.Stages = slices.Clone(s.Stages)
+	s.Moves = nil
+	for i := range s.Stages {
+		s.Stages[i].Moves = Simplify(s.Stages[i].Moves)
+		s.Moves = append(s.Moves, s.Stages[i].Moves...)
+	}
+	return s
+}
+
+// checkSolution verifies that moves solve c, so solvers never hand back a
+// sequence that doesn't work
+func checkSolution(c *Cube, moves []Move) error {
+	check := c.Clone()
+	check.ApplyMoves(moves)
+	if !check.IsSolved() {
+		return errors.New("solution does not solve the cube")
+	}
+	return nil
+}
+
+// reversalSolver undoes the recorded move history
+type reversalSolver struct {
+	history []Move
+	moves   []Move // the allowed moves, if restricted
+}
+
+func init() {
+	Register("reversal", func(opts Options) Solver {
+		return reversalSolver{history: opts.History, moves: opts.Moves}
+	})
+}
+
+func (reversalSolver) Name() string { return "reversal" }
+
+func (reversalSolver) Capabilities() Capabilities {
+	return Capabilities{HumanReadable: true, MoveSets: true}
+}
+
+func (s reversalSolver) Solve(ctx context.Context, c *Cube) (Solution, error) {
+	if len(s.history) == 0 && !c.IsSolved() {
+		return Solution{}, errors.New("no move history to reverse")
+	}
+	moves := Simplify(Invert(s.history))
+	if err := checkSolution(c, moves); err != nil {
+		return Solution{}, errors.New("reversing the move history does not solve the cube; it was changed some other way")
+	}
+	for _, m := range moves {
+		if s.moves != nil && !slices.Contains(s.moves, m) {
+			return Solution{}, fmt.Errorf("reversing the move history takes %s, outside %s", m, FormatMoveSet(s.moves))
+		}
+	}
+	return Solution{Solver: s.Name(), Moves: moves}, nil
+}
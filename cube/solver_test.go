@@ -0,0 +1,143 @@
+package cube
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// failingSolver always fails with err
+type failingSolver struct{ err error }
+
+func (failingSolver) Name() string               { return "failing" }
+func (failingSolver) Capabilities() Capabilities { return Capabilities{} }
+func (s failingSolver) Solve(context.Context, *Cube) (Solution, error) {
+	return Solution{}, s.err
+}
+
+func TestChainFallsBack(t *testing.T) {
+	history := []Move{R, U, Ri, Ui}
+	c := NewCube()
+	c.ApplyMoves(history)
+
+	reversal, err := NewSolver("reversal", Options{History: history})
+	if err != nil {
+		t.Fatal(err)
+	}
+	chain := Chain{failingSolver{errors.New("broken")}, reversal}
+	solution, err := chain.Solve(context.Background(), c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if solution.Solver != "reversal" || FormatMoves(solution.Moves) != "U R U' R'" {
+		t.Fatalf("got %s solution %s", solution.Solver, FormatMoves(solution.Moves))
+	}
+}
+
+// stagedSolver returns its stages, whatever the cube
+type stagedSolver []Stage
+
+func (stagedSolver) Name() string               { return "staged" }
+func (stagedSolver) Capabilities() Capabilities { return Capabilities{} }
+func (s stagedSolver) Solve(context.Context, *Cube) (Solution, error) {
+	var moves []Move
+	for _, stage := range s {
+		moves = append(moves, stage.Moves...)
+	}
+	return Solution{Solver: "staged", Moves: moves, Stages: s}, nil
+}
+
+func TestChainSimplifiesWithinStages(t *testing.T) {
+	chain := Chain{stagedSolver{
+		{Name: "one", Moves: MustParseMoves("D2 U D2 R")},
+		{Name: "two", Moves: MustParseMoves("R' L")},
+	}}
+	solution, err := chain.Solve(context.Background(), NewCube())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// R and R' meet across the join, but each stage keeps its own moves
+	for i, want := range []string{"U R", "R' L"} {
+		if got := FormatMoves(solution.Stages[i].Moves); got != want {
+			t.Errorf("stage %d: got %q, want %q", i+1, got, want)
+		}
+	}
+	if got := FormatMoves(solution.Moves); got != "U R R' L" {
+		t.Errorf("got %q, want \"U R R' L\"", got)
+	}
+}
+
+func TestChainKeepsMethodStages(t *testing.T) {
+	// Stages end and start with turns of the same face, and a rotation
+	// between turns of U; the chain must hand them on as the method wrote
+	// them
+	c := NewCube()
+	c.ApplyMoves(MustParseMoves("R U R' U' F2 D L' B2 U"))
+	for _, name := range []string{"beginner", "cfop", "roux", "zz", "thistlethwaite"} {
+		s, err := NewSolver(name, Options{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		own, err := s.Solve(context.Background(), c)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		chain, err := NewChain([]string{name}, Options{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		solution, err := chain.Solve(context.Background(), c)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		for i, stage := range solution.Stages {
+			if got, want := FormatMoves(stage.Moves), FormatMoves(own.Stages[i].Moves); got != want || stage.Skipped != own.Stages[i].Skipped {
+				t.Errorf("%s %s: got %s through the chain, want %s", name, stage.Name, got, want)
+			}
+		}
+	}
+}
+
+func TestChainReportsEveryFailure(t *testing.T) {
+	c := NewCube()
+	c.ApplyMove(R)
+
+	chain, err := NewChain([]string{"reversal"}, Options{History: []Move{U}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	chain = append(Chain{failingSolver{errors.New("broken")}}, chain...)
+	_, err = chain.Solve(context.Background(), c)
+	if err == nil {
+		t.Fatal("expected the chain to fail")
+	}
+	for _, want := range []string{"failing: broken", "reversal: "} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+}
+
+func TestNewSolverUnknown(t *testing.T) {
+	if _, err := NewSolver("nope", Options{}); err == nil {
+		t.Fatal("expected an error for an unknown solver")
+	}
+}
+
+func TestNewSolverMoveSets(t *testing.T) {
+	set, _ := ParseMoveSet("<R,U>")
+	if _, err := NewSolver("cfop", Options{Moves: set}); err == nil {
+		t.Error("expected cfop to refuse a move set")
+	}
+
+	c := NewCube()
+	c.ApplyMoves([]Move{R, F})
+	reversal, err := NewSolver("reversal", Options{History: []Move{R, F}, Moves: set})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reversal.Solve(context.Background(), c); err == nil {
+		t.Error("expected reversal to refuse a history outside <R,U>")
+	}
+}
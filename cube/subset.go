@@ -0,0 +1,736 @@
+package cube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Subset-restricted solving: solutions that use only a given set of moves,
+// e.g. <R,U> or <M,U>. The search-based solvers run this instead of their
+// own phases when Options.Moves is set, since those assume every face can
+// turn.
+//
+// The search is IDA* over cubie cubes. Its bound is the largest of a few
+// pruning tables, each giving the exact distance of one group of pieces
+// to where a solved cube has them, found by a breadth-first walk from the
+// solved cube using the set's moves alone. A piece group that the walk
+// never reaches proves the cube can't be solved with those moves; so, for
+// the whole cube, does the group of permutations the moves generate not
+// holding it (see permgroup.go). Close to
+// the goal the bound is exact: the walk is also made over whole cubes, as
+// far as a budget of states allows, so the search need only go half way.
+// As every move counts one, the solutions come shortest first.
+//
+// The goal needn't be the solved cube: a Goal (see goal.go) names the
+// pieces that must end solved and the stickers that must match their
+// centers, leaving the rest free, and may leave a final turn of U undone.
+// The walks follow pieces, not stickers, so a sticker is bounded instead
+// by the moves that bring one of the right face to its place.
+
+// subsetMaxDepth is the default bound on the length of restricted
+// solutions, in moves
+const subsetMaxDepth = 20
+
+// ErrUnreachable reports a cube that can't be solved with the allowed moves
+var ErrUnreachable = errors.New("not reachable with the allowed moves")
+
+// ParseMoveSet parses a set of allowed moves, e.g. "<R,U>", "R U F" or
+// "<U, D, R2, L2>". A move without an amount stands for all its turns (R,
+// R2 and R'); a half turn stands for itself.
+func ParseMoveSet(s string) ([]Move, error) {
+	s = strings.TrimSpace(s)
+	s = strings.TrimSuffix(strings.TrimPrefix(s, "<"), ">")
+	var set []Move
+	add := func(m Move) {
+		if !slices.Contains(set, m) {
+			set = append(set, m)
+		}
+	}
+	for _, field := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' }) {
+		m, err := ParseMove(field)
+		if err != nil {
+			return nil, fmt.Errorf("move set: %w", err)
+		}
+		base, turns := splitMove(m)
+		if strings.ContainsAny(base, "xyz") {
+			return nil, fmt.Errorf("move set: %s turns the whole cube", field)
+		}
+		if turns == 2 {
+			add(m)
+			continue
+		}
+		for _, suffix := range []string{"", "2", "'"} {
+			add(Move(base + suffix))
+		}
+	}
+	if len(set) == 0 {
+		return nil, errors.New("move set: no moves")
+	}
+	return set, nil
+}
+
+// FormatMoveSet writes a move set in the form ParseMoveSet reads, e.g.
+// "<R,U>"
+func FormatMoveSet(moves []Move) string {
+	turns := map[string][]int{}
+	var bases []string
+	for _, m := range moves {
+		base, t := splitMove(m)
+		if _, ok := turns[base]; !ok {
+			bases = append(bases, base)
+		}
+		turns[base] = append(turns[base], t)
+	}
+	names := make([]string, len(bases))
+	for i, base := range bases {
+		names[i] = base
+		if t := turns[base]; len(t) == 1 && t[0] == 2 {
+			names[i] += "2"
+		}
+	}
+	return "<" + strings.Join(names, ",") + ">"
+}
+
+// restriction is a move set and the limits of a search within it, as set
+// by Options
+type restriction struct {
+	moves        []Move
+	maxDepth     int
+	maxSolutions int // negative for every solution within maxDepth
+	cost         *CostModel
+}
+
+// restrictionOf returns the restriction opts ask for, or nil if they allow
+// every move
+func restrictionOf(opts Options) *restriction {
+	if len(opts.Moves) == 0 {
+		return nil
+	}
+	r := &restriction{moves: opts.Moves, maxDepth: opts.MaxDepth, maxSolutions: opts.MaxSolutions, cost: opts.Cost}
+	if r.maxDepth <= 0 {
+		r.maxDepth = subsetMaxDepth
+	}
+	if r.maxSolutions == 0 {
+		r.maxSolutions = 1
+	}
+	return r
+}
+
+// solve is Solver.Solve for a solver called name, restricted to r's moves.
+// With a cost model, every solution as long as the last one wanted is
+// found, so the quickest of them can be picked.
+func (r *restriction) solve(ctx context.Context, c *Cube, name string) (Solution, error) {
+	q := subsetQuery{moves: r.moves, goal: solveAll, maxDepth: r.maxDepth, n: r.maxSolutions, wholeDepths: r.cost != nil}
+	solutions, err := solveSubset(ctx, c, q)
+	if err != nil {
+		return Solution{}, err
+	}
+	if r.cost != nil {
+		slices.SortStableFunc(solutions, r.cost.compare)
+		if r.maxSolutions > 0 {
+			solutions = solutions[:min(len(solutions), r.maxSolutions)]
+		}
+	}
+	return Solution{Solver: name, Moves: solutions[0], Alternatives: solutions[1:]}, nil
+}
+
+// SolveSubset returns up to n solutions of c that use only the given
+// moves, shortest first, each at most maxDepth moves long. A negative n
+// asks for every such solution. It returns ErrUnreachable if no sequence of
+// the moves solves c.
+func SolveSubset(ctx context.Context, c *Cube, moves []Move, maxDepth, n int) ([][]Move, error) {
+	return solveSubset(ctx, c, subsetQuery{moves: moves, goal: solveAll, maxDepth: maxDepth, n: n})
+}
+
+// subsetQuery is what a restricted search looks for
+type subsetQuery struct {
+	moves    []Move
+	goal     subsetGoal
+	maxDepth int
+	n        int // solutions wanted, negative for all
+
+	// wholeDepths finishes the depth the n-th solution turns up at, so
+	// that every solution of the lengths returned is there
+	wholeDepths bool
+}
+
+func solveSubset(ctx context.Context, c *Cube, q subsetQuery) ([][]Move, error) {
+	set := FormatMoveSet(q.moves)
+	if err := c.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", set, err)
+	}
+	cc, err := cubieFromFacelets(c.KociembaString())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", set, err)
+	}
+	t, err := loadSubsetTables(q.moves, q.goal)
+	if err != nil {
+		return nil, err
+	}
+	if t.bound(&cc) == unreachable || !t.reachable(&cc) {
+		return nil, fmt.Errorf("%s: %w", set, ErrUnreachable)
+	}
+
+	search := &subsetSearch{ctx: ctx, tables: t, want: q.n}
+	if q.wholeDepths {
+		search.want = -1
+	}
+	for depth := t.bound(&cc); depth <= q.maxDepth; depth++ {
+		search.dfs(&cc, depth, -1)
+		if search.stopped {
+			if len(search.solutions) > 0 {
+				break
+			}
+			return nil, ctx.Err()
+		}
+		if q.n >= 0 && len(search.solutions) >= q.n {
+			break
+		}
+	}
+	if len(search.solutions) == 0 {
+		return nil, fmt.Errorf("%s: no solution within %d moves", set, q.maxDepth)
+	}
+	for _, solution := range search.solutions {
+		v := cc
+		v.ApplyMoves(solution)
+		if !t.solved(&v) {
+			return nil, fmt.Errorf("%s: solution does not reach the goal", set)
+		}
+		if q.goal == solveAll {
+			if err := checkSolution(c, solution); err != nil {
+				return nil, fmt.Errorf("%s: %v", set, err)
+			}
+		}
+	}
+	return search.solutions, nil
+}
+
+// subsetGoal is what a restricted search must reach: every piece in its
+// masks solved and every sticker matching its center, up to a final turn
+// of U if auf is set (see Goal)
+type subsetGoal struct {
+	corners  uint8  // bit c for each corner c that must end solved
+	edges    uint16 // bit e for each edge e that must end solved
+	stickers uint64 // bit k for each facelet k of the Kociemba string
+	auf      bool
+}
+
+// solveAll is the goal of solving the whole cube
+var solveAll = subsetGoal{corners: 1<<8 - 1, edges: 1<<12 - 1}
+
+func (g subsetGoal) String() string {
+	return fmt.Sprintf("%02x-%03x-%014x-%t", g.corners, g.edges, g.stickers, g.auf)
+}
+
+// unreachable marks a pruning table entry the moves never reach
+const unreachable = 0xff
+
+// subsetNearStates bounds the whole-cube states the walk from the goal
+// records
+const subsetNearStates = 1 << 19
+
+// subsetTables are the pruning tables of one move set and goal
+type subsetTables struct {
+	moves  []Move
+	goal   subsetGoal
+	cubies []CubieCube // the moves' cubie cubes
+	axis   []int       // each move's axis, see moveAxis
+	layer  []int       // which layers each move turns, in a fixed order per axis
+	goals  []CubieCube // the cubes that reach the goal, up to pieces it ignores
+	prune  []subsetPrune
+
+	// With stickers in the goal, turns are the cubes of the final turns of
+	// U it allows, rotations the keys of its rotations without them, and
+	// stickerDist the fewest moves that bring a sticker of the right face
+	// to each of them, from each place (see stickerPlace). The distances
+	// are left out if the moves turn the centers.
+	turns       []CubieCube
+	rotations   map[subsetKey]bool
+	stickers    []int // facelets of the Kociemba string
+	places      []int // the stickers' places
+	stickerDist [][48]uint8
+
+	// group is the group the moves generate, if the goal is a whole cube
+	group *permGroup
+
+	// near holds the distance of every state within nearDepth moves of
+	// the goal, telling apart only the pieces the goal cares about
+	near      map[subsetKey]uint8
+	nearDepth int
+}
+
+// subsetPrune is one pruning table: coord's distance to a goal
+type subsetPrune struct {
+	coord func(*CubieCube) int
+	dist  []uint8
+}
+
+// subsetKey is a cube as the goal sees it: where each of the pieces it
+// cares about is, and the centers
+type subsetKey [2]uint64
+
+// The piece groups the pruning tables follow: the corners and edges of
+// each layer, and the centers
+var (
+	subsetCornerGroups = [][]Corner{{URF, UFL, ULB, UBR}, {DFR, DLF, DBL, DRB}}
+	subsetEdgeGroups   = []edgeGroup{{UR, UF, UL, UB}, {DR, DF, DL, DB}, {FR, FL, BL, BR}}
+)
+
+var (
+	subsetMu    sync.Mutex
+	subsetCache = map[string]*subsetTables{}
+)
+
+// loadSubsetTables returns the tables of a move set and goal, generating
+// them on first use. They take up to a second, so they are kept in memory
+// for as long as the program runs, but not on disk.
+func loadSubsetTables(moves []Move, goal subsetGoal) (*subsetTables, error) {
+	var set []Move
+	for _, m := range moves {
+		canonical, err := ParseMove(string(m))
+		if err != nil {
+			return nil, err
+		}
+		if base, _ := splitMove(canonical); strings.ContainsAny(base, "xyz") {
+			return nil, fmt.Errorf("%s turns the whole cube", m)
+		}
+		// The tables measure distance both ways, so the set must hold
+		// each move's inverse
+		for _, m := range []Move{canonical, canonical.Inverse()} {
+			if !slices.Contains(set, m) {
+				set = append(set, m)
+			}
+		}
+	}
+	if len(set) == 0 {
+		return nil, errors.New("no moves allowed")
+	}
+	key := FormatMoves(set) + " " + goal.String()
+
+	subsetMu.Lock()
+	defer subsetMu.Unlock()
+	if t, ok := subsetCache[key]; ok {
+		return t, nil
+	}
+	t := generateSubsetTables(set, goal)
+	subsetCache[key] = t
+	return t, nil
+}
+
+func generateSubsetTables(set []Move, goal subsetGoal) *subsetTables {
+	t := &subsetTables{moves: set, goal: goal}
+	var bases []string
+	for _, m := range set {
+		t.cubies = append(t.cubies, moveCubies[m])
+		base, _ := splitMove(m)
+		if !slices.Contains(bases, base) {
+			bases = append(bases, base)
+		}
+		t.axis = append(t.axis, moveAxis(base))
+		t.layer = append(t.layer, slices.Index(bases, base))
+	}
+
+	// A solve may end with the whole cube turned, if the moves turn the
+	// centers; the goals are the rotations whose centers they reach, each
+	// followed by any turn of U if that is left undone
+	centers := t.table(36, (*CubieCube).centerCoord, []CubieCube{SolvedCubie()})
+	auf := []CubieCube{SolvedCubie()}
+	if goal.auf {
+		for _, m := range []Move{"U", "U2", "U'"} {
+			auf = append(auf, moveCubies[m])
+		}
+	}
+	t.rotations = map[subsetKey]bool{}
+	for _, r := range rotationCubies {
+		if centers[r.centerCoord()] == unreachable {
+			continue
+		}
+		t.rotations[t.key(&r)] = true
+		for _, a := range auf {
+			t.goals = append(t.goals, r.Multiply(&a))
+		}
+	}
+	t.turns = auf
+	if goal.stickers != 0 {
+		t.stickerTables(len(t.rotations) > 1)
+	}
+
+	add := func(size int, coord func(*CubieCube) int) {
+		t.prune = append(t.prune, subsetPrune{coord, t.table(size, coord, t.goals)})
+	}
+	add(36, (*CubieCube).centerCoord)
+	for _, g := range subsetCornerGroups {
+		var masked []Corner
+		for _, c := range g {
+			if goal.corners&(1<<c) != 0 {
+				masked = append(masked, c)
+			}
+		}
+		if len(masked) > 0 {
+			add(pow(24, len(masked)), func(cc *CubieCube) int { return cc.cornerGroupCoord(masked) })
+		}
+	}
+	for _, g := range subsetEdgeGroups {
+		var masked edgeGroup
+		for _, e := range g {
+			if goal.edges&(1<<e) != 0 {
+				masked = append(masked, e)
+			}
+		}
+		if len(masked) > 0 {
+			add(masked.size(), func(cc *CubieCube) int { return cc.edgeGroupCoord(masked) })
+		}
+	}
+	if goal.corners == solveAll.corners && goal.edges == solveAll.edges && goal.stickers == 0 {
+		perms := make([]facePerm, len(t.cubies))
+		for i := range t.cubies {
+			perms[i] = t.cubies[i].facePerm()
+		}
+		t.group = newPermGroup(perms)
+	}
+	t.walkNear()
+	return t
+}
+
+// reachable reports whether some sequence of the moves takes cc to one of
+// the goals. Only whole cube goals are checked; for the rest the pruning
+// tables are all there is.
+func (t *subsetTables) reachable(cc *CubieCube) bool {
+	if t.group == nil {
+		return true
+	}
+	// cc followed by the moves must be a goal, so the moves must make
+	// cc's inverse followed by the goal
+	inv := cc.facePerm().inverse()
+	for _, g := range t.goals {
+		if t.group.contains(inv.then(g.facePerm())) {
+			return true
+		}
+	}
+	return false
+}
+
+// table walks breadth-first from the goals using the set's moves, giving
+// the distance of each value of coord to the nearest goal. Values never
+// reached are unreachable.
+func (t *subsetTables) table(size int, coord func(*CubieCube) int, goals []CubieCube) []uint8 {
+	dist := make([]uint8, size)
+	for i := range dist {
+		dist[i] = unreachable
+	}
+	var queue []CubieCube
+	for _, g := range goals {
+		if i := coord(&g); dist[i] == unreachable {
+			dist[i] = 0
+			queue = append(queue, g)
+		}
+	}
+	for n := 0; n < len(queue); n++ {
+		cc := queue[n]
+		d := dist[coord(&cc)]
+		for m := range t.cubies {
+			next := cc.Multiply(&t.cubies[m])
+			if i := coord(&next); dist[i] == unreachable {
+				dist[i] = d + 1
+				queue = append(queue, next)
+			}
+		}
+	}
+	return dist
+}
+
+// walkNear records the states around the goal, a whole layer of moves at
+// a time for as long as they fit in subsetNearStates
+func (t *subsetTables) walkNear() {
+	t.near = map[subsetKey]uint8{}
+	var layer []CubieCube
+	for _, g := range t.goals {
+		if k := t.key(&g); !t.recorded(k) {
+			t.near[k] = 0
+			layer = append(layer, g)
+		}
+	}
+	for depth := 1; len(layer) > 0; depth++ {
+		var next []CubieCube
+		found := map[subsetKey]bool{}
+		for _, cc := range layer {
+			for m := range t.cubies {
+				n := cc.Multiply(&t.cubies[m])
+				if k := t.key(&n); !t.recorded(k) && !found[k] {
+					found[k] = true
+					next = append(next, n)
+				}
+			}
+			if len(t.near)+len(next) > subsetNearStates {
+				return
+			}
+		}
+		for k := range found {
+			t.near[k] = uint8(depth)
+		}
+		t.nearDepth, layer = depth, next
+	}
+	// The walk reached every state: anything else is unreachable
+	t.nearDepth = unreachable - 1
+}
+
+func (t *subsetTables) recorded(k subsetKey) bool {
+	_, ok := t.near[k]
+	return ok
+}
+
+// key returns cc as the goal sees it, five bits for each piece position
+// (the piece and its orientation, or 31 if the goal ignores it)
+func (t *subsetTables) key(cc *CubieCube) subsetKey {
+	var k subsetKey
+	for i, c := range cc.CP {
+		v := uint64(31)
+		if t.goal.corners&(1<<c) != 0 {
+			v = uint64(c)*3 + uint64(cc.CO[i])
+		}
+		k[0] = k[0]<<5 | v
+	}
+	for i, e := range cc.EP {
+		v := uint64(31)
+		if t.goal.edges&(1<<e) != 0 {
+			v = uint64(e)*2 + uint64(cc.EO[i])
+		}
+		k[1] = k[1]<<5 | v
+	}
+	k[0] = k[0]<<6 | uint64(cc.centerCoord())
+	return k
+}
+
+// bound is a lower bound on the moves that take cc to the goal, or
+// unreachable. Without stickers in the goal it is 0 only at the goal.
+func (t *subsetTables) bound(cc *CubieCube) int {
+	h := 0
+	for _, p := range t.prune {
+		h = max(h, int(p.dist[p.coord(cc)]))
+	}
+	if h == unreachable {
+		return h
+	}
+	if d, ok := t.near[t.key(cc)]; ok {
+		h = int(d)
+	} else {
+		h = max(h, t.nearDepth+1)
+	}
+	if t.stickerDist != nil {
+		h = max(h, t.stickerBound(cc))
+	}
+	return h
+}
+
+// solved reports whether cc reaches the goal
+func (t *subsetTables) solved(cc *CubieCube) bool {
+	if t.goal.stickers == 0 {
+		d, ok := t.near[t.key(cc)]
+		return ok && d == 0
+	}
+	for _, a := range t.turns {
+		v := cc.Multiply(&a)
+		if t.rotations[t.key(&v)] && t.stickersMatch(&v) {
+			return true
+		}
+	}
+	return false
+}
+
+// stickerPlace numbers the places a sticker can be: 0-23 for the corner
+// facelets, 3 for each position, and 24-47 for the edge facelets
+func stickerPlace(k int) int {
+	for i, f := range cornerFacelet {
+		if j := slices.Index(f[:], k); j >= 0 {
+			return 3*i + j
+		}
+	}
+	for i, f := range edgeFacelet {
+		if j := slices.Index(f[:], k); j >= 0 {
+			return 24 + 2*i + j
+		}
+	}
+	return -1
+}
+
+// placeLetter returns the face letter of the sticker at place p of cc
+func placeLetter(cc *CubieCube, p int) byte {
+	if p < 24 {
+		i, j := p/3, p%3
+		return cornerColor[cc.CP[i]][(j+3-int(cc.CO[i]))%3]
+	}
+	i, j := (p-24)/2, p%2
+	return edgeColor[cc.EP[i]][(j+2-int(cc.EO[i]))%2]
+}
+
+// stickersMatch reports whether the goal's stickers match their centers
+func (t *subsetTables) stickersMatch(cc *CubieCube) bool {
+	for n, k := range t.stickers {
+		center := cc.Centers[kociembaFaceOrder[k/9]]
+		if placeLetter(cc, t.places[n]) != "FRBLUD"[center] {
+			return false
+		}
+	}
+	return true
+}
+
+// stickerTables lists the goal's stickers and, if the centers stay put,
+// how far each place is from them
+func (t *subsetTables) stickerTables(centersTurn bool) {
+	for k := 0; k < 54; k++ {
+		if t.goal.stickers&(1<<k) != 0 {
+			t.stickers = append(t.stickers, k)
+			t.places = append(t.places, stickerPlace(k))
+		}
+	}
+	if centersTurn {
+		return
+	}
+	// Where each move takes the sticker at each place
+	perm := func(cc *CubieCube) [48]int {
+		var to [48]int
+		for i := 0; i < 8; i++ {
+			for j := 0; j < 3; j++ {
+				to[3*int(cc.CP[i])+j] = 3*i + (j+int(cc.CO[i]))%3
+			}
+		}
+		for i := 0; i < 12; i++ {
+			for j := 0; j < 2; j++ {
+				to[24+2*int(cc.EP[i])+j] = 24 + 2*i + (j+int(cc.EO[i]))%2
+			}
+		}
+		return to
+	}
+	var moves [][48]int
+	for _, m := range t.cubies {
+		moves = append(moves, perm(&m))
+	}
+	for _, place := range t.places {
+		// With a final turn of U left undone, the sticker may end at any
+		// place the turns take this one to
+		var dist [48]uint8
+		for p := range dist {
+			dist[p] = unreachable
+		}
+		var queue []int
+		for _, a := range t.turns {
+			if p := perm(&a)[place]; dist[p] != 0 {
+				dist[p] = 0
+				queue = append(queue, p)
+			}
+		}
+		for n := 0; n < len(queue); n++ {
+			p := queue[n]
+			for _, to := range moves {
+				if q := to[p]; dist[q] == unreachable {
+					dist[q] = dist[p] + 1
+					queue = append(queue, q)
+				}
+			}
+		}
+		t.stickerDist = append(t.stickerDist, dist)
+	}
+}
+
+// stickerBound is the most moves any of the goal's stickers needs to bring
+// a sticker of the right face there
+func (t *subsetTables) stickerBound(cc *CubieCube) int {
+	h := 0
+	for n, k := range t.stickers {
+		want := "URFDLB"[k/9]
+		d := uint8(unreachable)
+		first, last := 0, 24
+		if t.places[n] >= 24 {
+			first, last = 24, 48
+		}
+		for p := first; p < last; p++ {
+			if placeLetter(cc, p) == want {
+				d = min(d, t.stickerDist[n][p])
+			}
+		}
+		h = max(h, int(d))
+	}
+	return h
+}
+
+// centerCoord encodes which centers are on U and F (0-35)
+func (cc *CubieCube) centerCoord() int {
+	return int(cc.Centers[Up])*6 + int(cc.Centers[Front])
+}
+
+// cornerGroupCoord encodes where the corners of g are and how they are
+// twisted, in base 24
+func (cc *CubieCube) cornerGroupCoord(g []Corner) int {
+	idx := 0
+	for _, c := range g {
+		idx = idx*24 + cc.cornerCoord(c)
+	}
+	return idx
+}
+
+// pow returns a to the power n
+func pow(a, n int) int {
+	r := 1
+	for ; n > 0; n-- {
+		r *= a
+	}
+	return r
+}
+
+// subsetSearch holds the state of one restricted IDA* search
+type subsetSearch struct {
+	ctx       context.Context
+	tables    *subsetTables
+	want      int // solutions wanted, negative for all
+	path      []int
+	solutions [][]Move
+	nodes     int
+	stopped   bool
+}
+
+func (s *subsetSearch) done() bool {
+	return s.want >= 0 && len(s.solutions) >= s.want
+}
+
+// dfs collects the solutions of cc of exactly togo moves, not starting
+// with a move that merges or commutes with prev
+func (s *subsetSearch) dfs(cc *CubieCube, togo, prev int) {
+	s.nodes++
+	if s.nodes&0xfff == 0 && s.ctx.Err() != nil {
+		s.stopped = true
+	}
+	if s.stopped || s.done() {
+		return
+	}
+	h := s.tables.bound(cc)
+	if togo == 0 {
+		if h == 0 && s.tables.solved(cc) {
+			moves := make([]Move, len(s.path))
+			for i, m := range s.path {
+				moves[i] = s.tables.moves[m]
+			}
+			s.solutions = append(s.solutions, moves)
+		}
+		return
+	}
+	// Passing through the goal makes a longer copy of a shorter solution
+	if h > togo || h == 0 && prev >= 0 && s.tables.solved(cc) {
+		return
+	}
+
+	t := s.tables
+	for m := range t.cubies {
+		// Moves on one axis commute, so take them in one order only
+		if prev >= 0 && t.axis[m] == t.axis[prev] && t.layer[m] <= t.layer[prev] {
+			continue
+		}
+		next := cc.Multiply(&t.cubies[m])
+		s.path = append(s.path, m)
+		s.dfs(&next, togo-1, m)
+		s.path = s.path[:len(s.path)-1]
+	}
+}
@@ -0,0 +1,147 @@
+package cube
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestParseMoveSet(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"<R,U>", "R R2 R' U U2 U'"},
+		{"R U", "R R2 R' U U2 U'"},
+		{"<U, D, R2>", "U U2 U' D D2 D' R2"},
+		{"<M,U'>", "M M2 M' U U2 U'"},
+		{"<r,U>", "Rw Rw2 Rw' U U2 U'"},
+	}
+	for _, tt := range tests {
+		got, err := ParseMoveSet(tt.in)
+		if err != nil {
+			t.Errorf("%q: %v", tt.in, err)
+			continue
+		}
+		if FormatMoves(got) != tt.want {
+			t.Errorf("%q: got %s, want %s", tt.in, FormatMoves(got), tt.want)
+		}
+	}
+	for _, bad := range []string{"", "<>", "<R,y>", "<R,Q>"} {
+		if _, err := ParseMoveSet(bad); err == nil {
+			t.Errorf("%q: expected an error", bad)
+		}
+	}
+	if got := FormatMoveSet(MustParseMoves("U U2 U' D R2 L2")); got != "<U,D,R2,L2>" {
+		t.Errorf("got %s, want <U,D,R2,L2>", got)
+	}
+}
+
+func TestSolveSubset(t *testing.T) {
+	tests := []struct {
+		set, scramble string
+		length        int
+	}{
+		{"<R,U>", "R U R' U R U2 R'", 7},
+		{"<R,U>", "R U2 R' U' R U R2 U R U' R' U2", 12},
+		{"<M,U>", "M' U2 M U", 4},
+		{"<R,U,F>", "R U F R' U' F' R", 7},
+		{"<U,D,R2,L2,F2,B2>", "U R2 D' F2 L2 U B2", 7},
+	}
+	for _, tt := range tests {
+		set, err := ParseMoveSet(tt.set)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c := NewCube()
+		c.ApplyMoves(MustParseMoves(tt.scramble))
+		solutions, err := SolveSubset(context.Background(), c, set, 20, 3)
+		if err != nil {
+			t.Fatalf("%s %s: %v", tt.set, tt.scramble, err)
+		}
+		if len(solutions) != 3 {
+			t.Fatalf("%s %s: got %d solutions, want 3", tt.set, tt.scramble, len(solutions))
+		}
+		if len(solutions[0]) != tt.length {
+			t.Errorf("%s %s: shortest solution %s, want %d moves", tt.set, tt.scramble, FormatMoves(solutions[0]), tt.length)
+		}
+		for i, solution := range solutions {
+			for _, m := range solution {
+				if !slices.Contains(set, m) {
+					t.Errorf("%s %s: %s uses %s", tt.set, tt.scramble, FormatMoves(solution), m)
+				}
+			}
+			if i > 0 && len(solution) < len(solutions[i-1]) {
+				t.Errorf("%s %s: %s comes after a longer solution", tt.set, tt.scramble, FormatMoves(solution))
+			}
+			check := c.Clone()
+			check.ApplyMoves(solution)
+			if !check.IsSolved() {
+				t.Errorf("%s %s: %s does not solve it", tt.set, tt.scramble, FormatMoves(solution))
+			}
+		}
+	}
+}
+
+func TestSolveSubsetEverySolution(t *testing.T) {
+	// Within two moves U has one solution, U': sequences like U2 U that
+	// turn a layer twice in a row are skipped
+	c := NewCube()
+	c.ApplyMove(U)
+	set, _ := ParseMoveSet("<R,U>")
+	solutions, err := SolveSubset(context.Background(), c, set, 2, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(solutions) != 1 || FormatMoves(solutions[0]) != "U'" {
+		var got []string
+		for _, s := range solutions {
+			got = append(got, FormatMoves(s))
+		}
+		t.Fatalf("got %q, want only U'", got)
+	}
+}
+
+func TestSolveSubsetUnreachable(t *testing.T) {
+	tests := []struct {
+		set, scramble string
+	}{
+		{"<R,U>", "F"},
+		{"<R,U>", "L"},
+		{"<M,U>", "R"},
+		{"<R2,U2>", "R"},
+		// Every piece group is reachable alone, but not the whole cube
+		{"<R,U>", "F R U' R' U' R U R' F' R U R' U' R' F R F'"},
+	}
+	for _, tt := range tests {
+		set, _ := ParseMoveSet(tt.set)
+		c := NewCube()
+		c.ApplyMoves(MustParseMoves(tt.scramble))
+		if _, err := SolveSubset(context.Background(), c, set, 20, 1); !errors.Is(err, ErrUnreachable) {
+			t.Errorf("%s %s: got %v, want ErrUnreachable", tt.set, tt.scramble, err)
+		}
+	}
+}
+
+func TestRestrictedSolvers(t *testing.T) {
+	c := NewCube()
+	c.ApplyMoves(MustParseMoves("R U R' U R U2 R'"))
+	set, _ := ParseMoveSet("<R,U>")
+	opts := Options{Moves: set, MaxDepth: 14, MaxSolutions: 2}
+	for _, name := range []string{"kociemba", "optimal", "thistlethwaite"} {
+		s, err := NewSolver(name, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		solution, err := s.Solve(context.Background(), c)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if got := FormatMoves(solution.Moves); got != "R U2 R' U' R U' R'" {
+			t.Errorf("%s: got %s, want R U2 R' U' R U' R'", name, got)
+		}
+		if len(solution.Alternatives) != 1 {
+			t.Errorf("%s: got %d alternatives, want 1", name, len(solution.Alternatives))
+		}
+	}
+}
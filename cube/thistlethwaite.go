@@ -0,0 +1,270 @@
+package cube
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Thistlethwaite's algorithm solves the cube through a chain of nested
+// subgroups, each generated by fewer moves than the last:
+//
+//	G0 = <U, D, R, L, F, B>         any cube
+//	G1 = <U, D, R, L, F2, B2>       edges oriented
+//	G2 = <U, D, R2, L2, F2, B2>     corners oriented, E-slice edges in the E slice
+//	G3 = <U2, D2, R2, L2, F2, B2>   every piece in a position half turns can solve
+//	G4 = {solved}
+//
+// Phase n takes the cube from G(n-1) into Gn using only the moves of
+// G(n-1). Each phase is an IDA* search over a few coordinates, guided by
+// pruning tables that give the exact distance to the phase's goal, so
+// every phase is solved in as few moves as its group allows. The result is
+// longer than a two-phase solution (usually 30-45 moves) but shows the
+// structure of the cube group, which is why it is taught.
+
+// thistlethwaitePhase is one step down the subgroup chain. A phase tracks
+// up to three coordinates; bound is a lower bound on the moves needed to
+// reach the phase's goal and is 0 exactly at the goal.
+type thistlethwaitePhase struct {
+	group       string // the subgroup the phase reaches
+	description string
+	moves       []int // the moves of the group the phase starts in
+	coords      func(cc *CubieCube) [3]int
+	move        [3][]uint16 // move tables of the coordinates, nil if unused
+	bound       func(x [3]int) int
+}
+
+// halfTurns are the moves of G3
+var halfTurns = []int{1, 4, 7, 10, 13, 16}
+
+// g1Moves are the moves of G1: every turn except quarter turns of F and B
+var g1Moves = []int{0, 1, 2, 3, 4, 5, 7, 9, 10, 11, 12, 13, 14, 16}
+
+const nMSlice = 70 // 8 choose 4 positions of the M-slice edges
+
+// mSlice encodes which of the eight U and D layer positions the M-slice
+// edges (UF, UB, DF, DB) occupy (0-69). It is only meaningful in G2, where
+// those positions hold the U and D layer edges.
+func (cc *CubieCube) mSlice() int {
+	idx, x := 0, 0
+	for j := DB; j >= UR; j-- {
+		if e := cc.EP[j]; e == UF || e == UB || e == DF || e == DB {
+			idx += binomial(int(DB-j), x+1)
+			x++
+		}
+	}
+	return idx
+}
+
+var (
+	thistlethwaiteOnce   sync.Once
+	thistlethwaiteTables [4]thistlethwaitePhase
+)
+
+// loadThistlethwaitePhases returns the four phases, generating their
+// tables on first use. They take well under a second to build, so unlike
+// the two-phase tables they aren't cached on disk.
+func loadThistlethwaitePhases() *[4]thistlethwaitePhase {
+	thistlethwaiteOnce.Do(func() {
+		thistlethwaiteTables = generateThistlethwaitePhases()
+	})
+	return &thistlethwaiteTables
+}
+
+func generateThistlethwaitePhases() [4]thistlethwaitePhase {
+	kt := loadKociembaTables()
+	allMoves := make([]int, nMoves)
+	for i := range allMoves {
+		allMoves[i] = i
+	}
+
+	// Phase 1: orient the edges
+	flipPrune := pruneTable(1, nFlip, make([]uint16, nMoves), kt.flipMove, allMoves)
+
+	// Phase 2: orient the corners and gather the E-slice edges
+	slicePos := func(cc *CubieCube) int { return cc.sliceSorted() / 24 }
+	slicePosMove := coordMoveTable(nSlice, slicePos, allMoves)
+	sliceTwistPrune := pruneTable(nSlice, nTwist, slicePosMove, kt.twistMove, g1Moves)
+
+	// Phase 3: gather the M-slice edges, and bring the corners into one of
+	// the 96 permutations half turns can reach. The goal is a set of
+	// corner permutations, found by walking the half turns from solved.
+	mSliceMove := coordMoveTable(nMSlice, (*CubieCube).mSlice, phase2Moves)
+	solved := SolvedCubie()
+	home := solved.mSlice()
+	seen := map[int]bool{0: true}
+	for queue := []int{0}; len(queue) > 0; queue = queue[1:] {
+		for _, m := range halfTurns {
+			if next := int(kt.cornerMove[queue[0]*nMoves+m]); !seen[next] {
+				seen[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	var goals []int
+	for corner := range seen {
+		goals = append(goals, corner*nMSlice+home)
+	}
+	cornerMSlicePrune := pruneTableFrom(nPerm8, nMSlice, kt.cornerMove, mSliceMove, phase2Moves, goals)
+
+	// Phase 4: solve with half turns, like the second phase of the
+	// two-phase algorithm with fewer moves
+	cornerPrune := pruneTable(nSlicePerm, nPerm8, kt.sliceMove, kt.cornerMove, halfTurns)
+	udEdgePrune := pruneTable(nSlicePerm, nPerm8, kt.sliceMove, kt.udEdgeMove, halfTurns)
+
+	return [4]thistlethwaitePhase{
+		{
+			group:       "<U,D,R,L,F2,B2>",
+			description: "orient every edge, so F and B quarter turns are no longer needed",
+			moves:       allMoves,
+			coords:      func(cc *CubieCube) [3]int { return [3]int{cc.flip()} },
+			move:        [3][]uint16{kt.flipMove},
+			bound:       func(x [3]int) int { return int(flipPrune[x[0]]) },
+		},
+		{
+			group:       "<U,D,R2,L2,F2,B2>",
+			description: "orient every corner and put the E-slice edges in the E slice, so R and L quarter turns are no longer needed",
+			moves:       g1Moves,
+			coords:      func(cc *CubieCube) [3]int { return [3]int{slicePos(cc), cc.twist()} },
+			move:        [3][]uint16{slicePosMove, kt.twistMove},
+			bound:       func(x [3]int) int { return int(sliceTwistPrune[x[0]*nTwist+x[1]]) },
+		},
+		{
+			group:       "<U2,D2,R2,L2,F2,B2>",
+			description: "put every edge in its slice and the corners in a permutation half turns can solve, so only half turns are needed",
+			moves:       phase2Moves,
+			coords:      func(cc *CubieCube) [3]int { return [3]int{cc.cornerPerm(), cc.mSlice()} },
+			move:        [3][]uint16{kt.cornerMove, mSliceMove},
+			bound:       func(x [3]int) int { return int(cornerMSlicePrune[x[0]*nMSlice+x[1]]) },
+		},
+		{
+			group:       "solved",
+			description: "solve the cube with half turns",
+			moves:       halfTurns,
+			coords: func(cc *CubieCube) [3]int {
+				return [3]int{cc.sliceSorted(), cc.cornerPerm(), cc.udEdgePerm()}
+			},
+			move: [3][]uint16{kt.sliceMove, kt.cornerMove, kt.udEdgeMove},
+			bound: func(x [3]int) int {
+				return int(max(cornerPrune[x[0]*nPerm8+x[1]], udEdgePrune[x[0]*nPerm8+x[2]]))
+			},
+		},
+	}
+}
+
+// SolveThistlethwaite solves the cube with Thistlethwaite's algorithm,
+// returning one stage per phase, named after the subgroup it reaches
+func (c *Cube) SolveThistlethwaite() (solution []Move, stages []Stage, err error) {
+	return solveThistlethwaite(context.Background(), c)
+}
+
+func solveThistlethwaite(ctx context.Context, c *Cube) (solution []Move, stages []Stage, err error) {
+	if err := c.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("thistlethwaite: %w", err)
+	}
+	cc, err := cubieFromFacelets(c.KociembaString())
+	if err != nil {
+		return nil, nil, fmt.Errorf("thistlethwaite: %v", err)
+	}
+
+	for n, phase := range loadThistlethwaitePhases() {
+		search := &thistlethwaiteSearch{ctx: ctx, phase: &phase}
+		x := phase.coords(&cc)
+		skipped := phase.bound(x) == 0
+		for depth := phase.bound(x); !search.dfs(x, depth, -1); depth++ {
+			if search.stopped {
+				return nil, nil, ctx.Err()
+			}
+		}
+
+		var names []string
+		for i := len(search.path) - 1; i >= 0; i-- {
+			m := search.path[i]
+			names = append(names, moveNames[m])
+			cc = cc.Multiply(&moveCubes[m])
+		}
+		moves := MustParseMoves(strings.Join(names, " "))
+		stages = append(stages, Stage{
+			Name:        fmt.Sprintf("Phase %d: %s", n+1, phase.group),
+			Description: phase.description,
+			Moves:       moves,
+			Skipped:     skipped,
+		})
+		solution = append(solution, moves...)
+	}
+	return solution, stages, nil
+}
+
+// thistlethwaiteSearch holds the state of one phase's IDA* search
+type thistlethwaiteSearch struct {
+	ctx     context.Context
+	phase   *thistlethwaitePhase
+	path    []int // the phase's moves, last first
+	nodes   int
+	stopped bool
+}
+
+// dfs looks for a way to the phase's goal from coordinates x in togo
+// moves, not starting with a move redundant after prev
+func (s *thistlethwaiteSearch) dfs(x [3]int, togo, prev int) bool {
+	s.nodes++
+	if s.nodes&0xfff == 0 && s.ctx.Err() != nil {
+		s.stopped = true
+	}
+	if s.stopped {
+		return false
+	}
+	h := s.phase.bound(x)
+	if togo == 0 || h > togo {
+		return h == 0 && togo == 0
+	}
+
+	for _, m := range s.phase.moves {
+		if prev >= 0 && redundant(prev, m) {
+			continue
+		}
+		next := x
+		for i, table := range s.phase.move {
+			if table != nil {
+				next[i] = int(table[x[i]*nMoves+m])
+			}
+		}
+		if s.dfs(next, togo-1, m) {
+			s.path = append(s.path, m)
+			return true
+		}
+	}
+	return false
+}
+
+// thistlethwaiteSolver is the registry adapter for SolveThistlethwaite
+type thistlethwaiteSolver struct {
+	restrict *restriction
+}
+
+func init() {
+	Register("thistlethwaite", func(opts Options) Solver {
+		return thistlethwaiteSolver{restrict: restrictionOf(opts)}
+	})
+}
+
+func (thistlethwaiteSolver) Name() string { return "thistlethwaite" }
+
+func (thistlethwaiteSolver) Capabilities() Capabilities {
+	return Capabilities{StepAnnotations: true, MoveSets: true}
+}
+
+func (s thistlethwaiteSolver) Solve(ctx context.Context, c *Cube) (Solution, error) {
+	if s.restrict != nil {
+		return s.restrict.solve(ctx, c, s.Name())
+	}
+	moves, stages, err := solveThistlethwaite(ctx, c)
+	if err != nil {
+		return Solution{}, err
+	}
+	if err := checkSolution(c, moves); err != nil {
+		return Solution{}, err
+	}
+	return Solution{Solver: s.Name(), Moves: moves, Stages: stages}, nil
+}
@@ -0,0 +1,85 @@
+package cube
+
+import "testing"
+
+// inGroup reports whether every move is one of the group's generators
+func inGroup(moves []Move, generators []int) bool {
+	for _, m := range moves {
+		found := false
+		for _, g := range generators {
+			found = found || string(m) == moveNames[g]
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSolveThistlethwaite(t *testing.T) {
+	phases := loadThistlethwaitePhases()
+	s := NewScrambler(1)
+	for i := 0; i < 50; i++ {
+		scramble := s.RandomMoves(25)
+		c := NewCube()
+		c.ApplyMoves(scramble)
+
+		solution, stages, err := c.SolveThistlethwaite()
+		if err != nil {
+			t.Fatalf("%s: %v", FormatMoves(scramble), err)
+		}
+		if len(stages) != 4 {
+			t.Fatalf("%s: got %d stages, want 4", FormatMoves(scramble), len(stages))
+		}
+
+		// Each phase uses only the moves of the group it starts in, and
+		// reaches the goal of its phase
+		cc, _ := cubieFromFacelets(c.KociembaString())
+		for n, stage := range stages {
+			if !inGroup(stage.Moves, phases[n].moves) {
+				t.Fatalf("%s: %s uses moves outside its group: %s", FormatMoves(scramble), stage.Name, FormatMoves(stage.Moves))
+			}
+			cc.ApplyMoves(stage.Moves)
+			if phases[n].bound(phases[n].coords(&cc)) != 0 {
+				t.Fatalf("%s: %s did not reach its goal", FormatMoves(scramble), stage.Name)
+			}
+		}
+		c.ApplyMoves(solution)
+		if !c.IsSolved() {
+			t.Fatalf("%s: solution %s left %s", FormatMoves(scramble), FormatMoves(solution), c.KociembaString())
+		}
+	}
+}
+
+func TestSolveThistlethwaiteStageNames(t *testing.T) {
+	c := NewCube()
+	c.ApplyMoves(MustParseMoves("R U F"))
+	_, stages, err := c.SolveThistlethwaite()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"Phase 1: <U,D,R,L,F2,B2>",
+		"Phase 2: <U,D,R2,L2,F2,B2>",
+		"Phase 3: <U2,D2,R2,L2,F2,B2>",
+		"Phase 4: solved",
+	}
+	for i, stage := range stages {
+		if stage.Name != want[i] {
+			t.Errorf("stage %d is %q, want %q", i, stage.Name, want[i])
+		}
+	}
+}
+
+func TestSolveThistlethwaiteAnyOrientation(t *testing.T) {
+	c := NewCubeScheme(Japanese)
+	c.ApplyMoves(MustParseMoves("x R U' z F2 D y B"))
+	solution, _, err := c.SolveThistlethwaite()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.ApplyMoves(solution)
+	if !c.IsSolved() {
+		t.Fatalf("solution %s left %s", FormatMoves(solution), c.KociembaString())
+	}
+}
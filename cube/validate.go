@@ -0,0 +1,304 @@
+package cube
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Check identifies one of the tests Validate runs
+type Check int
+
+// Validation checks, in the order they run. Piece checks only run once the
+// stickers add up, and the twist, flip and parity checks only once every
+// piece is real and appears once.
+const (
+	CheckColors       Check = iota // every sticker has a known color
+	CheckStickerCount              // nine stickers of each color
+	CheckCenters                   // six different center colors
+	CheckCorners                   // each corner's colors make a real corner
+	CheckEdges                     // each edge's colors make a real edge
+	CheckDuplicates                // no piece appears twice
+	CheckTwist                     // corner twists add up to a whole turn
+	CheckFlip                      // an even number of edges are flipped
+	CheckParity                    // corner and edge permutations are both even or both odd
+)
+
+func (c Check) String() string {
+	return [...]string{
+		"colors", "sticker count", "centers", "corners", "edges",
+		"duplicates", "twist", "flip", "parity",
+	}[c]
+}
+
+// Problem is one reason a cube state can't be reached by turning faces
+type Problem struct {
+	Check  Check
+	Pieces []string // positions involved, e.g. "URF", "UF" or stickers such as "F3"
+	Detail string
+}
+
+func (p Problem) String() string {
+	return p.Detail
+}
+
+// ValidationError lists every problem Validate found
+type ValidationError struct {
+	Problems []Problem
+}
+
+func (e *ValidationError) Error() string {
+	details := make([]string, len(e.Problems))
+	for i, p := range e.Problems {
+		details[i] = p.Detail
+	}
+	return "invalid cube: " + strings.Join(details, "; ")
+}
+
+// Validate checks that the cube could have been reached from a solved cube
+// by turning faces. It returns nil or a *ValidationError naming the
+// offending stickers and pieces. Pieces are judged against the centers, so
+// any orientation of the whole cube is accepted.
+func (c *Cube) Validate() error {
+	if problems := c.validateStickers(); len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+
+	// With the centers known, spell the cube out in Kociemba face letters
+	cc, problems := validatePieces(c.KociembaString(), c)
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	if problems := validateCubie(&cc); len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// validateStickers runs the color, count and center checks
+func (c *Cube) validateStickers() []Problem {
+	var problems []Problem
+	var count [6]int
+	for f := Face(0); f < 6; f++ {
+		for i := 0; i < 9; i++ {
+			col := c.faces[f][i]
+			if col < White || col > Yellow {
+				problems = append(problems, Problem{
+					Check:  CheckColors,
+					Pieces: []string{stickerName(f, i)},
+					Detail: fmt.Sprintf("sticker %s has unknown color %d", stickerName(f, i), int(col)),
+				})
+				continue
+			}
+			count[col]++
+		}
+	}
+	if len(problems) > 0 {
+		return problems
+	}
+
+	for col, n := range count {
+		if n != 9 {
+			problems = append(problems, Problem{
+				Check:  CheckStickerCount,
+				Detail: fmt.Sprintf("%d %s stickers, want 9", n, colorName(Color(col))),
+			})
+		}
+	}
+
+	var centerOf [6][]string
+	for f := Face(0); f < 6; f++ {
+		col := c.faces[f][4]
+		centerOf[col] = append(centerOf[col], f.String())
+	}
+	for col, faces := range centerOf {
+		if len(faces) > 1 {
+			problems = append(problems, Problem{
+				Check:  CheckCenters,
+				Pieces: faces,
+				Detail: fmt.Sprintf("%s is the center of %s", colorName(Color(col)), strings.Join(faces, " and ")),
+			})
+		}
+	}
+	return problems
+}
+
+// validatePieces identifies the piece at every position, reporting
+// positions whose colors make no real piece and pieces that appear twice
+func validatePieces(facelets string, c *Cube) (CubieCube, []Problem) {
+	var cc CubieCube
+	var problems []Problem
+
+	var cornerAt [8][]string
+	for i := URF; i <= DRB; i++ {
+		piece, twist, ok := cornerPiece(facelets, i)
+		if !ok {
+			problems = append(problems, Problem{
+				Check:  CheckCorners,
+				Pieces: []string{i.String()},
+				Detail: fmt.Sprintf("corner at %s is %s, which is not a real corner", i.String(), pieceColors(c, cornerFacelet[i][:])),
+			})
+			continue
+		}
+		cc.CP[i], cc.CO[i] = piece, twist
+		cornerAt[piece] = append(cornerAt[piece], i.String())
+	}
+
+	var edgeAt [12][]string
+	for i := UR; i <= BR; i++ {
+		piece, flip, ok := edgePiece(facelets, i)
+		if !ok {
+			problems = append(problems, Problem{
+				Check:  CheckEdges,
+				Pieces: []string{i.String()},
+				Detail: fmt.Sprintf("edge at %s is %s, which is not a real edge", i.String(), pieceColors(c, edgeFacelet[i][:])),
+			})
+			continue
+		}
+		cc.EP[i], cc.EO[i] = piece, flip
+		edgeAt[piece] = append(edgeAt[piece], i.String())
+	}
+
+	for _, at := range cornerAt {
+		if len(at) > 1 {
+			problems = append(problems, Problem{
+				Check:  CheckDuplicates,
+				Pieces: at,
+				Detail: fmt.Sprintf("the same corner is at %s", strings.Join(at, " and ")),
+			})
+		}
+	}
+	for _, at := range edgeAt {
+		if len(at) > 1 {
+			problems = append(problems, Problem{
+				Check:  CheckDuplicates,
+				Pieces: at,
+				Detail: fmt.Sprintf("the same edge is at %s", strings.Join(at, " and ")),
+			})
+		}
+	}
+	return cc, problems
+}
+
+// cornerPiece identifies the corner at position i of a facelet string and
+// its twist
+func cornerPiece(facelets string, i Corner) (piece Corner, twist int8, ok bool) {
+	for twist = 0; twist < 3; twist++ {
+		col := facelets[cornerFacelet[i][twist]]
+		if col == 'U' || col == 'D' {
+			break
+		}
+	}
+	if twist == 3 {
+		return 0, 0, false
+	}
+	col0 := facelets[cornerFacelet[i][twist]]
+	col1 := facelets[cornerFacelet[i][(twist+1)%3]]
+	col2 := facelets[cornerFacelet[i][(twist+2)%3]]
+	for j := URF; j <= DRB; j++ {
+		if col0 == cornerColor[j][0] && col1 == cornerColor[j][1] && col2 == cornerColor[j][2] {
+			return j, twist, true
+		}
+	}
+	return 0, 0, false
+}
+
+// edgePiece identifies the edge at position i of a facelet string and its
+// flip
+func edgePiece(facelets string, i Edge) (piece Edge, flip int8, ok bool) {
+	a := facelets[edgeFacelet[i][0]]
+	b := facelets[edgeFacelet[i][1]]
+	for j := UR; j <= BR; j++ {
+		if a == edgeColor[j][0] && b == edgeColor[j][1] {
+			return j, 0, true
+		}
+		if a == edgeColor[j][1] && b == edgeColor[j][0] {
+			return j, 1, true
+		}
+	}
+	return 0, 0, false
+}
+
+// validateCubie runs the twist, flip and parity checks on a cube whose
+// pieces are all real and distinct
+func validateCubie(cc *CubieCube) []Problem {
+	var problems []Problem
+
+	twist := 0
+	var twisted []string
+	for i, o := range cc.CO {
+		twist += int(o)
+		if o != 0 {
+			twisted = append(twisted, Corner(i).String())
+		}
+	}
+	if twist%3 != 0 {
+		direction := "clockwise"
+		if twist%3 == 2 {
+			direction = "counter-clockwise"
+		}
+		problems = append(problems, Problem{
+			Check:  CheckTwist,
+			Pieces: twisted,
+			Detail: fmt.Sprintf("a corner is twisted %s in place (twisted corners: %s)", direction, strings.Join(twisted, ", ")),
+		})
+	}
+
+	flip := 0
+	var flipped []string
+	for i, o := range cc.EO {
+		flip += int(o)
+		if o != 0 {
+			flipped = append(flipped, Edge(i).String())
+		}
+	}
+	if flip%2 != 0 {
+		problems = append(problems, Problem{
+			Check:  CheckFlip,
+			Pieces: flipped,
+			Detail: fmt.Sprintf("an edge is flipped in place (flipped edges: %s)", strings.Join(flipped, ", ")),
+		})
+	}
+
+	if permParity(cc.CP[:]) != permParity(cc.EP[:]) {
+		var misplaced []string
+		for i, p := range cc.CP {
+			if p != Corner(i) {
+				misplaced = append(misplaced, Corner(i).String())
+			}
+		}
+		for i, p := range cc.EP {
+			if p != Edge(i) {
+				misplaced = append(misplaced, Edge(i).String())
+			}
+		}
+		problems = append(problems, Problem{
+			Check:  CheckParity,
+			Pieces: misplaced,
+			Detail: fmt.Sprintf("two pieces are swapped (misplaced pieces: %s)", strings.Join(misplaced, ", ")),
+		})
+	}
+	return problems
+}
+
+// stickerName names sticker i of face f as the face letter and a 1-based
+// index, e.g. "F3"
+func stickerName(f Face, i int) string {
+	return fmt.Sprintf("%s%d", f, i+1)
+}
+
+// pieceColors spells out the colors of a piece's stickers, e.g.
+// "white-green-red"
+func pieceColors(c *Cube, facelets []int) string {
+	names := make([]string, len(facelets))
+	for n, k := range facelets {
+		names[n] = colorName(c.facelet(k))
+	}
+	return strings.Join(names, "-")
+}
+
+func colorName(col Color) string {
+	if col < White || col > Yellow {
+		return fmt.Sprintf("color %d", int(col))
+	}
+	return [...]string{"white", "red", "blue", "orange", "green", "yellow"}[col]
+}
@@ -0,0 +1,115 @@
+package cube
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+// swapStickers exchanges two stickers of c
+func swapStickers(c *Cube, f1 Face, i1 int, f2 Face, i2 int) {
+	a, b := c.Sticker(f1, i1), c.Sticker(f2, i2)
+	c.SetSticker(f1, i1, b)
+	c.SetSticker(f2, i2, a)
+}
+
+func TestValidateAcceptsReachableCubes(t *testing.T) {
+	c := NewCube()
+	if err := c.Validate(); err != nil {
+		t.Fatalf("solved cube: %v", err)
+	}
+	c.ApplyMoves(MustParseMoves("D2 F' L2 U B' R2 F L' D R U2 B2 L F2 D' R' B U' L2 F R"))
+	if err := c.Validate(); err != nil {
+		t.Fatalf("scrambled cube: %v", err)
+	}
+}
+
+func TestValidateProblems(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *Cube)
+		check  Check
+		pieces []string
+	}{
+		{
+			name:   "unknown color",
+			modify: func(c *Cube) { c.SetSticker(Front, 2, Color(9)) },
+			check:  CheckColors,
+			pieces: []string{"F3"},
+		},
+		{
+			name:   "sticker count",
+			modify: func(c *Cube) { c.SetSticker(Front, 0, White) },
+			check:  CheckStickerCount,
+		},
+		{
+			name:   "duplicate center",
+			modify: func(c *Cube) { swapStickers(c, Front, 4, Up, 0) },
+			check:  CheckCenters,
+			pieces: []string{"F", "U"},
+		},
+		{
+			name: "impossible corner",
+			// Two corners trade a sticker, leaving a mirror image of the
+			// white-orange-green corner at URF
+			modify: func(c *Cube) { swapStickers(c, Right, 0, Left, 2) },
+			check:  CheckCorners,
+			pieces: []string{"URF"},
+		},
+		{
+			name: "duplicate edge",
+			modify: func(c *Cube) {
+				// UF becomes a second copy of UR and DR a second copy of DF
+				c.SetSticker(Front, 1, Red)
+				c.SetSticker(Right, 7, Green)
+			},
+			check:  CheckDuplicates,
+			pieces: []string{"UR", "UF"},
+		},
+		{
+			name: "twisted corner",
+			modify: func(c *Cube) {
+				swapStickers(c, Up, 8, Right, 0)
+				swapStickers(c, Up, 8, Front, 2)
+			},
+			check:  CheckTwist,
+			pieces: []string{"URF"},
+		},
+		{
+			name:   "flipped edge",
+			modify: func(c *Cube) { swapStickers(c, Up, 7, Front, 1) },
+			check:  CheckFlip,
+			pieces: []string{"UF"},
+		},
+		{
+			name: "swapped edges",
+			modify: func(c *Cube) {
+				swapStickers(c, Up, 5, Up, 7)
+				swapStickers(c, Right, 1, Front, 1)
+			},
+			check:  CheckParity,
+			pieces: []string{"UR", "UF"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCube()
+			tt.modify(c)
+			err := c.Validate()
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("Validate() = %v, want a *ValidationError", err)
+			}
+			for _, p := range verr.Problems {
+				if p.Check == tt.check {
+					if tt.pieces != nil && !slices.Equal(p.Pieces, tt.pieces) {
+						t.Errorf("%s problem names %v, want %v", p.Check, p.Pieces, tt.pieces)
+					}
+					return
+				}
+			}
+			t.Fatalf("no %s problem in %v", tt.check, err)
+		})
+	}
+}
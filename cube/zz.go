@@ -0,0 +1,209 @@
+package cube
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// ZZ method solver
+// Solves the way ZZ solvers do, with white on the bottom: EOLine makes
+// every edge good on the F/B axis (see eo.go) and solves the two white
+// edges on that axis; the rest of the cross then makes EOCross, and F2L
+// follows turning only R, U and L. With every edge good the last layer's
+// edges are already oriented, so OLL is one of the seven OCLL cases, then
+// PLL. The F2L pairs and the last layer are solved as in CFOP.
+
+// lineEdges are the edges of the line, on the D face along the F/B axis
+var lineEdges = edgeGroup{DF, DB}
+
+// eoCrossMoves are the moves that keep every edge good: no quarter turns
+// of F or B, and no half turns either, which wouldn't help the cross
+var eoCrossMoves = []int{0, 1, 2, 3, 4, 5, 9, 10, 11, 12, 13, 14}
+
+// zzTables are the pruning tables of the EOLine and EOCross searches,
+// both exact distances
+type zzTables struct {
+	flipMove  []uint16
+	lineMove  []uint16
+	linePrune []uint8 // flip x line: distance to EOLine
+	lineHome  int
+
+	crossPrune []uint8 // distance to the cross keeping every edge good
+}
+
+var (
+	zzOnce  sync.Once
+	zzTable *zzTables
+)
+
+// loadZZTables returns the ZZ tables, generating them on first use. They
+// take a fraction of a second, so they aren't cached on disk.
+func loadZZTables() *zzTables {
+	zzOnce.Do(func() {
+		solved := SolvedCubie()
+		line := func(cc *CubieCube) int { return cc.edgeGroupCoord(lineEdges) }
+		t := &zzTables{
+			flipMove: coordMoveTable(nFlip, (*CubieCube).flip, allMoveIndices()),
+			lineMove: coordMoveTable(lineEdges.size(), line, allMoveIndices()),
+			lineHome: line(&solved),
+		}
+		t.linePrune = pruneTableFrom(nFlip, lineEdges.size(), t.flipMove, t.lineMove, allMoveIndices(),
+			[]int{t.lineHome})
+
+		ct := loadCFOPTables()
+		t.crossPrune = pruneTableFrom(1, crossEdges.size(), make([]uint16, nMoves), ct.crossMove, eoCrossMoves,
+			[]int{ct.crossHome})
+		zzTable = t
+	})
+	return zzTable
+}
+
+// eoLine returns the shortest moves that take cc to EOLine
+func (t *zzTables) eoLine(cc *CubieCube) ([]Move, error) {
+	n := lineEdges.size()
+	path, err := descend(cc.flip()*n+cc.edgeGroupCoord(lineEdges),
+		func(s int) uint8 { return t.linePrune[s] },
+		func(s, m int) int {
+			return int(t.flipMove[s/n*nMoves+m])*n + int(t.lineMove[s%n*nMoves+m])
+		},
+		allMoveIndices())
+	if err != nil {
+		return nil, err
+	}
+	return moveIndexNames(path), nil
+}
+
+// eoCross returns the shortest moves that take cc from EOLine to EOCross
+func (t *zzTables) eoCross(cc *CubieCube) ([]Move, error) {
+	ct := loadCFOPTables()
+	path, err := descend(cc.crossCoord(),
+		func(s int) uint8 { return t.crossPrune[s] },
+		func(s, m int) int { return int(ct.crossMove[s*nMoves+m]) },
+		eoCrossMoves)
+	if err != nil {
+		return nil, err
+	}
+	return moveIndexNames(path), nil
+}
+
+// IsEOLineComplete reports whether every edge is good on the F/B axis and
+// the D edges on that axis are solved, with the cube as it is held
+func (c *Cube) IsEOLineComplete() bool {
+	return len(c.BadEdges(AxisFB)) == 0 && c.piecesSolved(nil, []Edge{DF, DB})
+}
+
+// IsEOCrossComplete reports whether every edge is good on the F/B axis and
+// the D cross is solved, with the cube as it is held
+func (c *Cube) IsEOCrossComplete() bool {
+	return len(c.BadEdges(AxisFB)) == 0 && c.piecesSolved(nil, []Edge{DR, DF, DL, DB})
+}
+
+// SolveZZ solves the cube with the ZZ method, returning its stages: the
+// rotations that turn white to the bottom and the side with the shortest
+// EOLine to the front, EOLine, EOCross, four F2L pairs, OCLL and PLL. The
+// moves after the rotations are written as the cube is then held, so F2L
+// turns only R, U and L and the last layer algorithms read as learnt.
+func (c *Cube) SolveZZ() (solution []Move, stages []Stage, err error) {
+	return solveZZ(context.Background(), c, nil)
+}
+
+func solveZZ(ctx context.Context, c *Cube, cost *CostModel) (solution []Move, stages []Stage, err error) {
+	if err := c.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("zz: %w", err)
+	}
+	hold, _ := c.holding(func(h *Cube) bool { return h.faces[Down][4] == White })
+	t := loadZZTables()
+
+	// Which edges are bad depends on which side faces front; take the
+	// side with the shortest EOLine, or the quickest of those
+	var cube *Cube
+	var view rotation
+	var line []Move
+	for k := 0; k < 4; k++ {
+		r := rotationY.times(k)
+		held := c.held(hold).held(r)
+		cc, _ := cubieFromFacelets(held.KociembaString())
+		moves, err := t.eoLine(&cc)
+		if err != nil {
+			return nil, nil, fmt.Errorf("zz: %w", err)
+		}
+		if cube == nil || cost.shorter(moves, line) {
+			cube, view, line = held, r, moves
+		}
+	}
+
+	s := &cfopSolve{ctx: ctx, tables: loadCFOPTables(), cube: cube, f2lFaces: []Face{Right, Up, Left}, cost: cost}
+	s.stages = append(s.stages, inspection(hold.then(view), "hold the cube with the white center on the bottom and the quickest EOLine at the front"))
+	d := colorName(cube.faces[Down][4])
+	f, b := colorName(cube.faces[Front][4]), colorName(cube.faces[Back][4])
+	description := fmt.Sprintf("solve the %s-%s and %s-%s edges", d, f, d, b)
+	if bad := len(cube.BadEdges(AxisFB)); bad > 0 {
+		description = fmt.Sprintf("orient the %d bad edges and %s", bad, description)
+	}
+	s.add("EOLine", description, line, cube.IsEOLineComplete())
+
+	eoCross := func() error {
+		cc := s.cubie()
+		moves, err := t.eoCross(&cc)
+		if err != nil {
+			return err
+		}
+		s.add("EOCross", "finish the "+d+" cross without turning F or B", moves, s.cube.IsEOCrossComplete())
+		return nil
+	}
+	for _, step := range []func() error{eoCross, s.f2l, s.ocll, s.pll} {
+		if err := step(); err != nil {
+			return nil, nil, fmt.Errorf("zz: %w", err)
+		}
+	}
+	for _, stage := range s.stages {
+		solution = append(solution, stage.Moves...)
+	}
+	return solution, s.stages, nil
+}
+
+// ocll orients the last layer's corners; its edges are already oriented
+func (s *cfopSolve) ocll() error {
+	cc := s.cubie()
+	pre, oll, err := recognizeOLL(&cc)
+	if err != nil {
+		return err
+	}
+	if oll < 0 {
+		s.add("OCLL skip", "the last layer is already oriented", nil, true)
+		return nil
+	}
+	if p := ollPatternOf(&cc); [4]int8(p[4:]) != [4]int8{} {
+		return fmt.Errorf("last layer edges are not oriented")
+	}
+	moves := append(append([]Move(nil), auf[pre]...), MustParseMoves(ollCases[oll].alg)...)
+	s.add("OCLL "+ollCases[oll].name, "orient the last layer's corners", moves, false)
+	return nil
+}
+
+// zzSolver is the registry adapter for SolveZZ
+type zzSolver struct {
+	cost *CostModel
+}
+
+func init() {
+	Register("zz", func(opts Options) Solver { return zzSolver{cost: opts.Cost} })
+}
+
+func (zzSolver) Name() string { return "zz" }
+
+func (zzSolver) Capabilities() Capabilities {
+	return Capabilities{HumanReadable: true, StepAnnotations: true}
+}
+
+func (s zzSolver) Solve(ctx context.Context, c *Cube) (Solution, error) {
+	moves, stages, err := solveZZ(ctx, c, s.cost)
+	if err != nil {
+		return Solution{}, err
+	}
+	if err := checkSolution(c, moves); err != nil {
+		return Solution{}, err
+	}
+	return Solution{Solver: s.Name(), Moves: moves, Stages: stages}, nil
+}
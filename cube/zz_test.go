@@ -0,0 +1,165 @@
+package cube
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSolveZZ(t *testing.T) {
+	s := NewScrambler(1)
+	for i := 0; i < 30; i++ {
+		scramble := s.RandomMoves(25)
+		c := NewCube()
+		c.ApplyMoves(scramble)
+
+		solution, stages, err := c.SolveZZ()
+		if err != nil {
+			t.Fatalf("%s: %v", FormatMoves(scramble), err)
+		}
+		var names []string
+		for _, stage := range stages {
+			names = append(names, strings.Fields(stage.Name)[0])
+		}
+		if got := strings.Join(names, " "); got != "Inspection EOLine EOCross F2L F2L F2L F2L OCLL PLL" {
+			t.Fatalf("%s: stages %s", FormatMoves(scramble), got)
+		}
+
+		// Held as the rotations leave it, white down, the line stays
+		// complete through F2L, which turns only R, U and L
+		c.ApplyMoves(stages[0].Moves)
+		for _, stage := range stages[1:7] {
+			c.ApplyMoves(stage.Moves)
+			if !c.IsEOLineComplete() {
+				t.Fatalf("%s: %s %s left %s", FormatMoves(scramble), stage.Name, FormatMoves(stage.Moves), c.KociembaString())
+			}
+		}
+		for _, stage := range stages[3:7] {
+			for _, m := range stage.Moves {
+				if f := moveFace(m); f != Right && f != Up && f != Left {
+					t.Fatalf("%s: %s uses %s", FormatMoves(scramble), stage.Name, m)
+				}
+			}
+		}
+		if !c.piecesSolved([]Corner{DFR, DLF, DBL, DRB}, []Edge{DR, DF, DL, DB, FR, FL, BL, BR}) {
+			t.Fatalf("%s: F2L left %s", FormatMoves(scramble), c.KociembaString())
+		}
+		for _, stage := range stages[7:] {
+			c.ApplyMoves(stage.Moves)
+		}
+		if !c.IsSolved() {
+			t.Fatalf("%s: solution %s left %s", FormatMoves(scramble), FormatMoves(solution), c.KociembaString())
+		}
+	}
+}
+
+func TestSolveZZAnyOrientation(t *testing.T) {
+	c := NewCubeScheme(Japanese)
+	c.ApplyMoves(MustParseMoves("x R U' z F2 D y B L2 U"))
+	solution, _, err := c.SolveZZ()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.ApplyMoves(solution)
+	if !c.IsSolved() {
+		t.Fatalf("solution %s left %s", FormatMoves(solution), c.KociembaString())
+	}
+}
+
+func TestSolveZZNamesCases(t *testing.T) {
+	// A Sune and a T perm from a solved cube, white on the bottom. The
+	// Sune ends with R' and the T perm starts with R; through a chain, as
+	// users get them, both still read as learnt.
+	c := NewCube()
+	c.ApplyMoves(MustParseMoves("x2"))
+	c.ApplyMoves(Invert(MustParseMoves(pllCases[15].alg)))
+	c.ApplyMoves(Invert(MustParseMoves(ollCases[26].alg)))
+	chain, err := NewChain([]string{"zz"}, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	solution, err := chain.Solve(context.Background(), c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, stage := range solution.Stages[:7] {
+		if len(stage.Moves) != 0 {
+			t.Errorf("%s: %s, want no moves", stage.Name, FormatMoves(stage.Moves))
+		}
+	}
+	for i, want := range []Stage{
+		{Name: "OCLL 27 (Sune)", Moves: MustParseMoves(ollCases[26].alg)},
+		{Name: "PLL T", Moves: MustParseMoves(pllCases[15].alg)},
+	} {
+		if got := solution.Stages[7+i]; got.Name != want.Name || FormatMoves(got.Moves) != FormatMoves(want.Moves) {
+			t.Errorf("got %s: %s, want %s: %s", got.Name, FormatMoves(got.Moves), want.Name, FormatMoves(want.Moves))
+		}
+	}
+}
+
+func TestBadEdges(t *testing.T) {
+	tests := []struct {
+		moves      string
+		fb, rl, ud int
+	}{
+		{"", 0, 0, 0},
+		{"F", 4, 0, 0},
+		{"R", 0, 4, 0},
+		{"U", 0, 0, 4},
+		{"F2 R2 U2", 0, 0, 0},
+		{"y", 0, 0, 0},
+		{"x", 0, 0, 0},
+	}
+	for _, tt := range tests {
+		c := NewCube()
+		c.ApplyMoves(MustParseMoves(tt.moves))
+		for axis, want := range []int{tt.fb, tt.rl, tt.ud} {
+			if got := len(c.BadEdges(Axis(axis))); got != want {
+				t.Errorf("%q: %d bad edges on the %s axis, want %d", tt.moves, got, Axis(axis), want)
+			}
+		}
+	}
+}
+
+func TestBadEdgesMatchesEdgeOrientation(t *testing.T) {
+	// On the F/B axis, with the cube held as solved, the bad edges are the
+	// flipped ones of the piece model
+	s := NewScrambler(1)
+	for i := 0; i < 50; i++ {
+		c := NewCube()
+		c.ApplyMoves(s.RandomMoves(20))
+		cc, err := c.Cubie()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var flipped []Edge
+		for e := UR; e <= BR; e++ {
+			if cc.EO[e] != 0 {
+				flipped = append(flipped, e)
+			}
+		}
+		if got := c.BadEdges(AxisFB); !slices.Equal(got, flipped) {
+			t.Fatalf("bad edges %v, want %v", got, flipped)
+		}
+	}
+}
+
+func TestIsEOLineComplete(t *testing.T) {
+	c := NewCube()
+	c.ApplyMoves(MustParseMoves("x2"))
+	if !c.IsEOLineComplete() || !c.IsEOCrossComplete() {
+		t.Fatal("solved cube is not EOCross")
+	}
+	c.ApplyMoves(MustParseMoves("R U L' U2 R2"))
+	if !c.IsEOLineComplete() {
+		t.Error("R, U and L broke EOLine")
+	}
+	if c.IsEOCrossComplete() {
+		t.Error("R2 left the cross complete")
+	}
+	c.ApplyMoves(MustParseMoves("F"))
+	if c.IsEOLineComplete() {
+		t.Error("F left EOLine complete")
+	}
+}
@@ -5,7 +5,6 @@ go 1.25.5
 require (
 	github.com/charmbracelet/bubbletea v1.3.10
 	github.com/charmbracelet/lipgloss v1.1.0
-	github.com/daosyn/kociemba v0.0.0-20181114055205-131b754e5c85
 )
 
 require (
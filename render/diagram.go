@@ -0,0 +1,376 @@
+package render
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/michaellavery-grp/rubiks-cube-solver/cube"
+)
+
+// Diagrams: pictures of a cube for docs and alg sheets, as SVG or PNG. A
+// diagram is built from filled convex polygons, stickers over the black
+// plastic behind them, so both formats draw the same shapes; PNG
+// rasterizes them itself, needing nothing beyond the standard library.
+
+// Layout is how a diagram shows the cube
+type Layout int
+
+// Diagram layouts
+const (
+	// LayoutNet unfolds every face around the front: up above, down below,
+	// then left, front, right and back in a row
+	LayoutNet Layout = iota
+	// Layout3D draws the cube in 3D from DiagramOptions.Camera
+	Layout3D
+	// LayoutTop shows the top face with the side stickers of the top layer
+	// around it, as OLL and PLL diagrams do
+	LayoutTop
+)
+
+var layoutNames = [...]string{"net", "3d", "top"}
+
+func (l Layout) String() string {
+	return layoutNames[l]
+}
+
+// ParseLayout reads a layout by name: net, 3d or top
+func ParseLayout(s string) (Layout, error) {
+	for l, name := range layoutNames {
+		if strings.EqualFold(s, name) {
+			return Layout(l), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown layout %q (want net, 3d or top)", s)
+}
+
+// DiagramOptions say how to draw a diagram. The zero value draws a net 300
+// pixels wide with every sticker in color.
+type DiagramOptions struct {
+	Layout Layout
+	// Width of the image in pixels; 0 means 300
+	Width int
+	// Camera is the viewpoint of Layout3D; the zero Camera means Isometric
+	Camera Camera
+	// Mask, if set, reports which stickers to color, by their place and
+	// color, as for a case that only cares about some pieces; the rest are
+	// grey. Centers are always colored.
+	Mask func(s cube.Sticker, c cube.Color) bool
+	// Arrows marks, in LayoutTop, where each top layer piece has to go, as
+	// PLL diagrams do; a swap gets one arrow with two heads
+	Arrows bool
+}
+
+// shape is a filled convex polygon of a diagram, in units of a cubie's
+// width with y pointing down
+type shape struct {
+	points []Point
+	fill   color.RGBA
+}
+
+// diagram is a drawing ready for output
+type diagram struct {
+	shapes        []shape
+	width, height float64
+}
+
+// Diagram colors
+var (
+	plastic     = color.RGBA{0x1a, 0x1a, 0x1a, 0xff}
+	maskedColor = color.RGBA{0x80, 0x80, 0x80, 0xff}
+	arrowColor  = color.RGBA{0x1a, 0x1a, 0x1a, 0xff}
+	colors      = [...]color.RGBA{
+		cube.White:  {0xff, 0xff, 0xff, 0xff},
+		cube.Red:    {0xc4, 0x1e, 0x3a, 0xff},
+		cube.Blue:   {0x00, 0x51, 0xba, 0xff},
+		cube.Orange: {0xff, 0x58, 0x00, 0xff},
+		cube.Green:  {0x00, 0x9e, 0x60, 0xff},
+		cube.Yellow: {0xff, 0xd5, 0x00, 0xff},
+	}
+)
+
+// gap is the plastic showing around a sticker in the flat layouts
+const gap = 0.06
+
+// WriteSVG writes a diagram of c as SVG
+func WriteSVG(w io.Writer, c *cube.Cube, opts DiagramOptions) error {
+	d := newDiagram(c, opts, Twist{})
+	scale := float64(opts.width()) / d.width
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" viewBox=\"0 0 %s %s\">\n",
+		opts.width(), int(math.Ceil(d.height*scale)), num(d.width), num(d.height))
+	for _, s := range d.shapes {
+		points := make([]string, len(s.points))
+		for i, p := range s.points {
+			points[i] = num(p.X) + "," + num(p.Y)
+		}
+		fmt.Fprintf(bw, "<polygon points=\"%s\" fill=\"#%02x%02x%02x\"/>\n", strings.Join(points, " "), s.fill.R, s.fill.G, s.fill.B)
+	}
+	fmt.Fprintln(bw, "</svg>")
+	return bw.Flush()
+}
+
+// num writes a coordinate briefly
+func num(x float64) string {
+	return fmt.Sprintf("%.4g", x)
+}
+
+// supersample is how many samples a PNG pixel takes across and down, for
+// smooth edges
+const supersample = 4
+
+// WritePNG writes a diagram of c as a PNG with a transparent background
+func WritePNG(w io.Writer, c *cube.Cube, opts DiagramOptions) error {
+	return png.Encode(w, newDiagram(c, opts, Twist{}).rasterize(opts.width(), supersample))
+}
+
+// rasterize draws the diagram width pixels wide on a transparent
+// background, each pixel averaging supersample by supersample samples
+func (d diagram) rasterize(width, supersample int) *image.NRGBA {
+	height := int(math.Ceil(d.height * float64(width) / d.width))
+	scale := float64(width*supersample) / d.width
+
+	// Draw each shape over the samples it covers, then average them
+	samples := make([]color.RGBA, width*supersample*height*supersample)
+	stride := width * supersample
+	for _, s := range d.shapes {
+		minX, minY, maxX, maxY := math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)
+		for _, p := range s.points {
+			minX, maxX = min(minX, p.X), max(maxX, p.X)
+			minY, maxY = min(minY, p.Y), max(maxY, p.Y)
+		}
+		for y := max(0, int(minY*scale)); y < min(height*supersample, int(maxY*scale)+1); y++ {
+			for x := max(0, int(minX*scale)); x < min(stride, int(maxX*scale)+1); x++ {
+				if convexContains(s.points, Point{(float64(x) + 0.5) / scale, (float64(y) + 0.5) / scale}) {
+					samples[y*stride+x] = s.fill
+				}
+			}
+		}
+	}
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var r, g, b, a int
+			for sy := 0; sy < supersample; sy++ {
+				for sx := 0; sx < supersample; sx++ {
+					s := samples[(y*supersample+sy)*stride+x*supersample+sx]
+					r += int(s.R) * int(s.A)
+					g += int(s.G) * int(s.A)
+					b += int(s.B) * int(s.A)
+					a += int(s.A)
+				}
+			}
+			if a > 0 {
+				img.SetNRGBA(x, y, color.NRGBA{uint8(r / a), uint8(g / a), uint8(b / a), uint8(a / (supersample * supersample))})
+			}
+		}
+	}
+	return img
+}
+
+func (opts DiagramOptions) width() int {
+	if opts.Width <= 0 {
+		return 300
+	}
+	return opts.Width
+}
+
+// newDiagram lays out c as opts ask, with t part way through in Layout3D
+func newDiagram(c *cube.Cube, opts DiagramOptions, t Twist) diagram {
+	fill := func(s cube.Sticker) color.RGBA {
+		col := c.Sticker(s.Face, s.Index)
+		if opts.Mask != nil && s.Index != 4 && !opts.Mask(s, col) {
+			return maskedColor
+		}
+		return colors[col]
+	}
+	switch opts.Layout {
+	case Layout3D:
+		return diagram3D(opts.Camera, t, fill)
+	case LayoutTop:
+		d := diagramTop(fill)
+		if opts.Arrows {
+			d.shapes = append(d.shapes, arrows(c)...)
+		}
+		return d
+	}
+	return diagramNet(fill)
+}
+
+// rect returns the axis-aligned rectangle w wide and h high at x, y
+func rect(x, y, w, h float64) []Point {
+	return []Point{{x, y}, {x + w, y}, {x + w, y + h}, {x, y + h}}
+}
+
+// diagramNet unfolds the faces: each is a block of plastic with its
+// stickers in reading order
+func diagramNet(fill func(cube.Sticker) color.RGBA) diagram {
+	at := map[cube.Face][2]float64{
+		cube.Up: {3, 0}, cube.Left: {0, 3}, cube.Front: {3, 3},
+		cube.Right: {6, 3}, cube.Back: {9, 3}, cube.Down: {3, 6},
+	}
+	d := diagram{width: 12, height: 9}
+	for f := cube.Front; f <= cube.Down; f++ {
+		x, y := at[f][0], at[f][1]
+		d.shapes = append(d.shapes, shape{rect(x, y, 3, 3), plastic})
+		for i := 0; i < 9; i++ {
+			s := cube.Sticker{Face: f, Index: i}
+			d.shapes = append(d.shapes, shape{rect(x+float64(i%3)+gap, y+float64(i/3)+gap, 1-2*gap, 1-2*gap), fill(s)})
+		}
+	}
+	return d
+}
+
+// sideDepth is how far the top layout's side stickers stand out
+const sideDepth = 0.4
+
+// diagramTop draws the top face from above with the top layer's side
+// stickers folded out flat around it
+func diagramTop(fill func(cube.Sticker) color.RGBA) diagram {
+	// The top face spans -1.5 to 1.5 in x and z, shifted into view
+	off := 1.5 + sideDepth + gap
+	d := diagram{width: 2 * off, height: 2 * off}
+	d.shapes = append(d.shapes, shape{rect(off-1.5, off-1.5, 3, 3), plastic})
+	for f := cube.Front; f <= cube.Down; f++ {
+		for i := 0; i < 9; i++ {
+			s := cube.Sticker{Face: f, Index: i}
+			pos, normal := s.Position()
+			if pos[1] != 1 {
+				continue
+			}
+			x, z := float64(pos[0]), float64(pos[2])
+			var r []Point
+			switch {
+			case f == cube.Up:
+				r = rect(off+x-0.5+gap, off+z-0.5+gap, 1-2*gap, 1-2*gap)
+			case normal[0] != 0:
+				edge := off + float64(normal[0])*(1.5+gap)
+				r = rect(min(edge, edge+float64(normal[0])*sideDepth), off+z-0.5+gap, sideDepth, 1-2*gap)
+			default:
+				edge := off + float64(normal[2])*(1.5+gap)
+				r = rect(off+x-0.5+gap, min(edge, edge+float64(normal[2])*sideDepth), 1-2*gap, sideDepth)
+			}
+			d.shapes = append(d.shapes, shape{r, fill(s)})
+		}
+	}
+	return d
+}
+
+// diagram3D draws what camera sees, with a zero camera meaning Isometric
+func diagram3D(camera Camera, t Twist, fill func(cube.Sticker) color.RGBA) diagram {
+	if camera == (Camera{}) {
+		camera = Isometric
+	}
+	r := camera.radius() + gap
+	d := diagram{width: 2 * r, height: 2 * r}
+	for _, p := range camera.Polygons(t) {
+		points := make([]Point, len(p.Points))
+		for i, q := range p.Points {
+			points[i] = Point{q.X + r, r - q.Y}
+		}
+		col := plastic
+		if !p.Body {
+			col = fill(p.Sticker)
+		}
+		d.shapes = append(d.shapes, shape{points, col})
+	}
+	return d
+}
+
+// arrows returns arrows for the top layer pieces of c that aren't where
+// they belong, pointing from each piece to its place, for the top layout
+func arrows(c *cube.Cube) []shape {
+	// A piece belongs where the centers next to it match its colors
+	centers := func(stickers []cube.Sticker) string {
+		var key []string
+		for _, s := range stickers {
+			key = append(key, c.Sticker(s.Face, 4).String())
+		}
+		return sortedKey(key)
+	}
+	colorsOf := func(stickers []cube.Sticker) string {
+		var key []string
+		for _, s := range stickers {
+			key = append(key, c.Sticker(s.Face, s.Index).String())
+		}
+		return sortedKey(key)
+	}
+	var places [][]cube.Sticker
+	for _, p := range []cube.Corner{cube.URF, cube.UFL, cube.ULB, cube.UBR} {
+		s := p.Stickers()
+		places = append(places, s[:])
+	}
+	for _, p := range []cube.Edge{cube.UR, cube.UF, cube.UL, cube.UB} {
+		s := p.Stickers()
+		places = append(places, s[:])
+	}
+	home := make([]int, len(places))
+	for i, piece := range places {
+		home[i] = -1
+		for j, place := range places {
+			if colorsOf(piece) == centers(place) {
+				home[i] = j
+			}
+		}
+	}
+	// Where on the top layout the top sticker of a place is
+	center := func(place []cube.Sticker) Point {
+		pos, _ := place[0].Position()
+		off := 1.5 + sideDepth + gap
+		return Point{off + float64(pos[0]), off + float64(pos[2])}
+	}
+	var out []shape
+	for i, j := range home {
+		if j < 0 || j == i {
+			continue
+		}
+		swap := home[j] == i
+		if swap && j < i {
+			continue // drawn from the other end
+		}
+		out = append(out, arrow(center(places[i]), center(places[j]), swap)...)
+	}
+	return out
+}
+
+// sortedKey joins parts in order, so a piece's colors match in any order
+func sortedKey(parts []string) string {
+	slices.Sort(parts)
+	return strings.Join(parts, "")
+}
+
+// arrow returns an arrow from a to b, a shaft and a head, with a head at a
+// too if both is set
+func arrow(a, b Point, both bool) []shape {
+	const (
+		inset = 0.22 // clear of the stickers' centers
+		shaft = 0.045
+		head  = 0.3
+		wide  = 0.14
+	)
+	dx, dy := b.X-a.X, b.Y-a.Y
+	length := math.Hypot(dx, dy)
+	ux, uy := dx/length, dy/length
+	nx, ny := -uy, ux
+	along := func(p Point, t, across float64) Point {
+		return Point{p.X + ux*t + nx*across, p.Y + uy*t + ny*across}
+	}
+	start, end := inset, length-inset-head
+	if both {
+		start += head
+	}
+	out := []shape{
+		{[]Point{along(a, start, -shaft), along(a, end, -shaft), along(a, end, shaft), along(a, start, shaft)}, arrowColor},
+		{[]Point{along(a, end, -wide), along(a, length-inset, 0), along(a, end, wide)}, arrowColor},
+	}
+	if both {
+		out = append(out, shape{[]Point{along(a, start, wide), along(a, inset, 0), along(a, start, -wide)}, arrowColor})
+	}
+	return out
+}
@@ -0,0 +1,101 @@
+package render
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/michaellavery-grp/rubiks-cube-solver/cube"
+)
+
+func TestParseLayout(t *testing.T) {
+	for _, l := range []Layout{LayoutNet, Layout3D, LayoutTop} {
+		if got, err := ParseLayout(strings.ToUpper(l.String())); err != nil || got != l {
+			t.Errorf("ParseLayout(%q) = %v, %v", l, got, err)
+		}
+	}
+	if _, err := ParseLayout("side"); err == nil {
+		t.Error("ParseLayout accepted side")
+	}
+}
+
+func TestDiagramShapes(t *testing.T) {
+	c := cube.NewCube()
+	tests := []struct {
+		opts DiagramOptions
+		want int
+	}{
+		{DiagramOptions{Layout: LayoutNet}, 6 + 54},
+		{DiagramOptions{Layout: Layout3D}, 3 + 27},
+		{DiagramOptions{Layout: LayoutTop}, 1 + 9 + 12},
+	}
+	for _, tt := range tests {
+		if got := len(newDiagram(c, tt.opts, Twist{}).shapes); got != tt.want {
+			t.Errorf("%s diagram has %d shapes, want %d", tt.opts.Layout, got, tt.want)
+		}
+	}
+}
+
+func TestDiagramArrows(t *testing.T) {
+	// A T perm swaps two edges and two corners: two arrows with two heads
+	c := cube.NewCube()
+	c.ApplyMoves(cube.MustParseMoves("R U R' U' R' F R2 U' R' U' R U R' F'"))
+	d := newDiagram(c, DiagramOptions{Layout: LayoutTop, Arrows: true}, Twist{})
+	if got := len(d.shapes) - (1 + 9 + 12); got != 2*3 {
+		t.Errorf("T perm has %d arrow shapes, want 6", got)
+	}
+	d = newDiagram(cube.NewCube(), DiagramOptions{Layout: LayoutTop, Arrows: true}, Twist{})
+	if got := len(d.shapes) - (1 + 9 + 12); got != 0 {
+		t.Errorf("solved cube has %d arrow shapes, want none", got)
+	}
+}
+
+func TestDiagramMask(t *testing.T) {
+	// Only the centers keep their colors
+	d := newDiagram(cube.NewCube(), DiagramOptions{Mask: func(cube.Sticker, cube.Color) bool { return false }}, Twist{})
+	colored := 0
+	for _, s := range d.shapes {
+		if s.fill != maskedColor && s.fill != plastic {
+			colored++
+		}
+	}
+	if colored != 6 {
+		t.Errorf("masked net colors %d stickers, want the 6 centers", colored)
+	}
+}
+
+func TestWriteSVG(t *testing.T) {
+	var b bytes.Buffer
+	if err := WriteSVG(&b, cube.NewCube(), DiagramOptions{Width: 120}); err != nil {
+		t.Fatal(err)
+	}
+	svg := b.String()
+	if !strings.HasPrefix(svg, "<svg") || !strings.Contains(svg, `width="120" height="90"`) {
+		t.Errorf("SVG starts %.100q", svg)
+	}
+	if got := strings.Count(svg, "<polygon"); got != 60 {
+		t.Errorf("SVG has %d polygons, want 60", got)
+	}
+}
+
+func TestWritePNG(t *testing.T) {
+	var b bytes.Buffer
+	if err := WritePNG(&b, cube.NewCube(), DiagramOptions{Layout: LayoutNet, Width: 120}); err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size := img.Bounds().Size(); size.X != 120 || size.Y != 90 {
+		t.Fatalf("PNG is %v, want 120x90", size)
+	}
+	// The middle of the front center is green; the corner is left clear
+	if r, g, b, _ := img.At(45, 45).RGBA(); g>>8 != 0x9e || r != 0 || b>>8 != 0x60 {
+		t.Errorf("front center is %x %x %x, want green", r>>8, g>>8, b>>8)
+	}
+	if _, _, _, a := img.At(2, 2).RGBA(); a != 0 {
+		t.Error("the net's corner isn't transparent")
+	}
+}
@@ -0,0 +1,81 @@
+package render
+
+import "image"
+
+// A small bitmap font for captions, 5 pixels wide and 7 high, with the
+// characters of move notation, move counts and "solved"
+var glyphs = map[rune][7]string{
+	'0':  {".###.", "#...#", "#..##", "#.#.#", "##..#", "#...#", ".###."},
+	'1':  {"..#..", ".##..", "..#..", "..#..", "..#..", "..#..", ".###."},
+	'2':  {".###.", "#...#", "....#", "...#.", "..#..", ".#...", "#####"},
+	'3':  {"#####", "...#.", "..#..", "...#.", "....#", "#...#", ".###."},
+	'4':  {"...#.", "..##.", ".#.#.", "#..#.", "#####", "...#.", "...#."},
+	'5':  {"#####", "#....", "####.", "....#", "....#", "#...#", ".###."},
+	'6':  {"..##.", ".#...", "#....", "####.", "#...#", "#...#", ".###."},
+	'7':  {"#####", "....#", "...#.", "..#..", ".#...", ".#...", ".#..."},
+	'8':  {".###.", "#...#", "#...#", ".###.", "#...#", "#...#", ".###."},
+	'9':  {".###.", "#...#", "#...#", ".####", "....#", "...#.", ".##.."},
+	'U':  {"#...#", "#...#", "#...#", "#...#", "#...#", "#...#", ".###."},
+	'R':  {"####.", "#...#", "#...#", "####.", "#.#..", "#..#.", "#...#"},
+	'F':  {"#####", "#....", "#....", "####.", "#....", "#....", "#...."},
+	'D':  {"###..", "#..#.", "#...#", "#...#", "#...#", "#..#.", "###.."},
+	'L':  {"#....", "#....", "#....", "#....", "#....", "#....", "#####"},
+	'B':  {"####.", "#...#", "#...#", "####.", "#...#", "#...#", "####."},
+	'M':  {"#...#", "##.##", "#.#.#", "#.#.#", "#...#", "#...#", "#...#"},
+	'E':  {"#####", "#....", "#....", "####.", "#....", "#....", "#####"},
+	'S':  {".####", "#....", "#....", ".###.", "....#", "....#", "####."},
+	'w':  {".....", ".....", "#...#", "#...#", "#.#.#", "#.#.#", ".#.#."},
+	'x':  {".....", ".....", "#...#", ".#.#.", "..#..", ".#.#.", "#...#"},
+	'y':  {".....", ".....", "#...#", "#...#", ".####", "....#", ".###."},
+	'z':  {".....", ".....", "#####", "...#.", "..#..", ".#...", "#####"},
+	's':  {".....", ".....", ".###.", "#....", ".###.", "....#", "####."},
+	'o':  {".....", ".....", ".###.", "#...#", "#...#", "#...#", ".###."},
+	'l':  {".##..", "..#..", "..#..", "..#..", "..#..", "..#..", ".###."},
+	'v':  {".....", ".....", "#...#", "#...#", "#...#", ".#.#.", "..#.."},
+	'e':  {".....", ".....", ".###.", "#...#", "#####", "#....", ".###."},
+	'd':  {"....#", "....#", ".##.#", "#..##", "#...#", "#...#", ".####"},
+	'\'': {"..#..", "..#..", ".#...", ".....", ".....", ".....", "....."},
+	'/':  {"....#", "...#.", "...#.", "..#..", ".#...", ".#...", "#...."},
+}
+
+// Glyph size in font pixels, with a column between characters
+const (
+	glyphWidth   = 5
+	glyphHeight  = 7
+	glyphAdvance = glyphWidth + 1
+)
+
+// textWidth is how many font pixels wide text is
+func textWidth(text string) int {
+	n := len([]rune(text))
+	if n == 0 {
+		return 0
+	}
+	return n*glyphAdvance - 1
+}
+
+// drawText sets the pixels of text to ink, each font pixel scale image
+// pixels square, with its top left corner at at. Characters the font
+// doesn't have are left blank.
+func drawText(img *image.Paletted, text string, at image.Point, scale int, ink uint8) {
+	for i, r := range []rune(text) {
+		g, ok := glyphs[r]
+		if !ok {
+			continue
+		}
+		for row, bits := range g {
+			for col := 0; col < len(bits); col++ {
+				if bits[col] != '#' {
+					continue
+				}
+				x := at.X + (i*glyphAdvance+col)*scale
+				y := at.Y + row*scale
+				for dy := 0; dy < scale; dy++ {
+					for dx := 0; dx < scale; dx++ {
+						img.SetColorIndex(x+dx, y+dy, ink)
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package render
+
+import "github.com/michaellavery-grp/rubiks-cube-solver/cube"
+
+// Kind is what a terminal cell of a Frame shows
+type Kind uint8
+
+// Cell kinds
+const (
+	Blank   Kind = iota // background around the cube
+	Body                // the cube's plastic between stickers
+	Colored             // a sticker, to be drawn in its color
+)
+
+// Cell is one terminal cell of a Frame
+type Cell struct {
+	Kind    Kind
+	Sticker cube.Sticker // the sticker, or the face of a body
+}
+
+// Frame rasterizes the camera's view of t into height rows of width cells. The
+// cube is centered and scaled to fit from any viewpoint, so it doesn't
+// change size as the camera turns. Terminal cells are about twice as tall
+// as they are wide, so a row spans twice the distance a column does.
+func (c Camera) Frame(t Twist, width, height int) [][]Cell {
+	polygons := c.Polygons(t)
+	r := c.radius()
+	rowsPerUnit := min(float64(height)/(2*r), float64(width)/(4*r))
+	frame := make([][]Cell, height)
+	for row := range frame {
+		frame[row] = make([]Cell, width)
+		for col := range frame[row] {
+			p := Point{
+				X: (float64(col) + 0.5 - float64(width)/2) / (2 * rowsPerUnit),
+				Y: (float64(height)/2 - float64(row) - 0.5) / rowsPerUnit,
+			}
+			for _, poly := range polygons {
+				if !poly.contains(p) {
+					continue
+				}
+				frame[row][col] = Cell{Kind: Colored, Sticker: poly.Sticker}
+				if poly.Body {
+					frame[row][col].Kind = Body
+				}
+			}
+		}
+	}
+	return frame
+}
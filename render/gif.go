@@ -0,0 +1,135 @@
+package render
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"io"
+	"time"
+
+	"github.com/michaellavery-grp/rubiks-cube-solver/cube"
+)
+
+// GIFOptions say how to animate a sequence of moves
+type GIFOptions struct {
+	DiagramOptions
+	// Frames is how many frames a move takes to turn in Layout3D; 0 means
+	// 6. The flat layouts show each move in a single frame.
+	Frames int
+	// MoveTime is how long each move shows for, turning and then at rest;
+	// 0 means 800 milliseconds
+	MoveTime time.Duration
+}
+
+// gifSupersample smooths GIF frames' edges less than PNGs', as the palette
+// has few blends for them, so that long solutions draw quickly
+const gifSupersample = 2
+
+// Frame timing, in GIF delays of a hundredth of a second
+const (
+	startDelay = 100 // the cube before the first move
+	endDelay   = 250 // the cube after the last one, before looping
+)
+
+// WriteGIF writes an animated GIF of moves made on c, one after another,
+// each captioned with its name and number, e.g. "R' 3/20". The last frame
+// says "solved" if the moves solve the cube. c is unchanged.
+func WriteGIF(w io.Writer, c *cube.Cube, moves []cube.Move, opts GIFOptions) error {
+	frames := opts.Frames
+	if frames <= 0 {
+		frames = 6
+	}
+	if opts.Layout != Layout3D {
+		frames = 1
+	}
+	moveTime := opts.MoveTime
+	if moveTime <= 0 {
+		moveTime = 800 * time.Millisecond
+	}
+	// Three fifths of a move's time turning, the rest at rest
+	turnDelay := max(2, int(moveTime*3/5/time.Millisecond)/10/frames)
+	restDelay := max(2, int(moveTime/time.Millisecond)/10-turnDelay*(frames-1))
+
+	width := opts.width()
+	scale := max(1, width/120) // caption font pixels
+	palette := gifPalette()
+	anim := &gif.GIF{}
+	add := func(c *cube.Cube, t Twist, caption string, delay int) {
+		img := newDiagram(c, opts.DiagramOptions, t).rasterize(width, gifSupersample)
+		anim.Image = append(anim.Image, paletted(img, palette, caption, scale))
+		anim.Delay = append(anim.Delay, delay)
+	}
+
+	state := c.Clone()
+	add(state, Twist{}, fmt.Sprintf("0/%d", len(moves)), startDelay)
+	for i, m := range moves {
+		caption := fmt.Sprintf("%s %d/%d", m, i+1, len(moves))
+		for f := 1; f < frames; f++ {
+			p := float64(f) / float64(frames)
+			add(state, Twist{Move: m, Progress: p * p * (3 - 2*p)}, caption, turnDelay)
+		}
+		state.ApplyMove(m)
+		delay := restDelay
+		if i == len(moves)-1 {
+			delay = endDelay
+			if state.IsSolved() {
+				caption = "solved"
+			}
+		}
+		add(state, Twist{}, caption, delay)
+	}
+	return gif.EncodeAll(w, anim)
+}
+
+// gifPalette holds the diagram colors on a white background, the caption's
+// ink, and blends of each color with the plastic for smooth edges
+func gifPalette() color.Palette {
+	white := color.RGBA{0xff, 0xff, 0xff, 0xff}
+	palette := color.Palette{white, plastic, maskedColor}
+	for _, c := range colors {
+		palette = append(palette, c)
+	}
+	for _, c := range append([]color.RGBA{white, maskedColor}, colors[:]...) {
+		for _, k := range []int{1, 2, 3} {
+			palette = append(palette, color.RGBA{
+				uint8((int(c.R)*k + int(plastic.R)*(4-k)) / 4),
+				uint8((int(c.G)*k + int(plastic.G)*(4-k)) / 4),
+				uint8((int(c.B)*k + int(plastic.B)*(4-k)) / 4),
+				0xff,
+			})
+		}
+	}
+	return palette
+}
+
+// paletted lays img over a white background in palette's nearest colors,
+// with a strip below it for the caption, centered
+func paletted(img *image.NRGBA, palette color.Palette, caption string, scale int) *image.Paletted {
+	b := img.Bounds()
+	strip := (glyphHeight + 4) * scale
+	out := image.NewPaletted(image.Rect(0, 0, b.Dx(), b.Dy()+strip), palette)
+	nearest := map[color.NRGBA]uint8{}
+	for y := 0; y < b.Dy(); y++ {
+		for x := 0; x < b.Dx(); x++ {
+			c := img.NRGBAAt(x, y)
+			i, ok := nearest[c]
+			if !ok {
+				// Over white
+				a := int(c.A)
+				over := color.RGBA{
+					uint8((int(c.R)*a + 0xff*(0xff-a)) / 0xff),
+					uint8((int(c.G)*a + 0xff*(0xff-a)) / 0xff),
+					uint8((int(c.B)*a + 0xff*(0xff-a)) / 0xff),
+					0xff,
+				}
+				i = uint8(palette.Index(over))
+				nearest[c] = i
+			}
+			out.SetColorIndex(x, y, i)
+		}
+	}
+	at := image.Pt((b.Dx()-textWidth(caption)*scale)/2, b.Dy()+2*scale)
+	drawText(out, caption, at, scale, 1)
+	return out
+}
@@ -0,0 +1,53 @@
+package render
+
+import (
+	"bytes"
+	"image/gif"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/michaellavery-grp/rubiks-cube-solver/cube"
+)
+
+func TestWriteGIF(t *testing.T) {
+	moves, _ := cube.ParseMoves("R U R' U'")
+	c := cube.NewCube()
+	c.ApplyMoves(cube.Invert(moves))
+	tests := []struct {
+		opts   GIFOptions
+		frames int
+		delays []int
+	}{
+		// The start, then each move's turning frames and its rest
+		{GIFOptions{DiagramOptions: DiagramOptions{Layout: Layout3D}, Frames: 4}, 1 + 4*4, []int{startDelay, 12, 12, 12, 44}},
+		{GIFOptions{DiagramOptions: DiagramOptions{Layout: LayoutNet}, MoveTime: time.Second}, 1 + 4, []int{startDelay, 100}},
+	}
+	for _, test := range tests {
+		test.opts.Width = 60
+		var buf bytes.Buffer
+		if err := WriteGIF(&buf, c, moves, test.opts); err != nil {
+			t.Fatal(err)
+		}
+		g, err := gif.DecodeAll(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(g.Image) != test.frames {
+			t.Errorf("%v: %d frames, want %d", test.opts.Layout, len(g.Image), test.frames)
+			continue
+		}
+		if got := g.Delay[:len(test.delays)]; !slices.Equal(got, test.delays) {
+			t.Errorf("%v: delays %v, want %v", test.opts.Layout, got, test.delays)
+		}
+		if last := g.Delay[len(g.Delay)-1]; last != endDelay {
+			t.Errorf("%v: last frame shows for %d, want %d", test.opts.Layout, last, endDelay)
+		}
+		if b := g.Image[0].Bounds(); b.Dx() != 60 || b.Dy() <= 45 {
+			t.Errorf("%v: frames are %v, want 60 wide with a caption below", test.opts.Layout, b)
+		}
+	}
+	if c.IsSolved() {
+		t.Error("WriteGIF changed the cube")
+	}
+}
@@ -0,0 +1,352 @@
+// Package render draws the cube in 3D from any viewpoint. A Camera looks at
+// the cube from some direction; Polygons projects the faces it can see and
+// their stickers onto the view plane, and Frame rasterizes those to a grid of
+// terminal cells. Colors are left to the caller, which looks each sticker up
+// on its cube, so one view serves any cube.
+package render
+
+import (
+	"cmp"
+	"math"
+	"slices"
+
+	"github.com/michaellavery-grp/rubiks-cube-solver/cube"
+)
+
+// Camera is a viewpoint on the cube. At zero yaw and pitch it looks straight
+// at the front face, with the top face up.
+type Camera struct {
+	// Yaw turns the camera around the vertical axis, in degrees; positive
+	// values move it round to the left face
+	Yaw float64
+	// Pitch raises the camera, in degrees from -90 (looking up at the
+	// bottom face) to 90 (looking down on the top face)
+	Pitch float64
+	// Distance is how far the camera is from the cube's center, in cubie
+	// widths. Near cameras see more perspective; 0 projects in parallel, as
+	// an isometric drawing does.
+	Distance float64
+}
+
+// Camera presets
+var (
+	// Perspective looks down on the top, left and front faces from close
+	// enough for the near corner to loom
+	Perspective = Camera{Yaw: 30, Pitch: 25, Distance: 8}
+	// Isometric shows the top, front and right faces equally, in parallel
+	Isometric = Camera{Yaw: -45, Pitch: math.Atan(1/math.Sqrt2) * 180 / math.Pi}
+)
+
+// Turn returns the camera moved around the cube by yaw and pitch degrees.
+// The pitch stops at the poles, so the top face stays up.
+func (c Camera) Turn(yaw, pitch float64) Camera {
+	c.Yaw = math.Mod(c.Yaw+yaw, 360)
+	switch {
+	case c.Yaw > 180:
+		c.Yaw -= 360
+	case c.Yaw <= -180:
+		c.Yaw += 360
+	}
+	c.Pitch = max(-90, min(90, c.Pitch+pitch))
+	return c
+}
+
+// Point is a position on the view plane, x to the right and y up, in cubie
+// widths from the cube's center
+type Point struct{ X, Y float64 }
+
+// Polygon is a projected quadrilateral: either a sticker or, if Body is
+// set, the whole face it sits on, which shows between the stickers
+type Polygon struct {
+	Sticker cube.Sticker // for a body, Index is the center's, or -1 inside the cube
+	Body    bool
+	Points  [4]Point
+	Depth   float64 // how far its center is along the view, larger being farther
+}
+
+// stickerHalf is half a sticker's width; the cubie's plastic shows around it
+const stickerHalf = 0.42
+
+// Twist is a move part way through, for animating it: the move's layers
+// turned by Progress of its angle, from 0 to 1. The zero Twist turns
+// nothing.
+type Twist struct {
+	Move     cube.Move
+	Progress float64
+}
+
+// slab is a block of neighbouring layers that turn together, or stay put
+type slab struct {
+	lo, hi  int // depths of its outer layers along the twist's face normal
+	turning bool
+}
+
+// Polygons returns what the camera sees of the cube, with t's layers part
+// way through turning, in the order to draw it: nearer polygons paint over
+// farther ones. Each face, or part of a face on a block of layers, comes
+// before its stickers. A turning layer opens up the inside of the cube,
+// which shows as body.
+func (c Camera) Polygons(t Twist) []Polygon {
+	face, depths, quarters, ok := t.Move.Layers()
+	if !ok {
+		face, depths = cube.Front, nil
+	}
+	_, n := cube.Sticker{Face: face, Index: 4}.Position()
+	axis := vec(n, 1)
+	// Clockwise looking at the face is a negative turn about its normal
+	angle := -float64(quarters) * t.Progress * math.Pi / 2
+
+	var slabs []slab
+	for d := -1; d <= 1; d++ {
+		turning := slices.Contains(depths, d)
+		if len(slabs) > 0 && slabs[len(slabs)-1].turning == turning {
+			slabs[len(slabs)-1].hi = d
+			continue
+		}
+		slabs = append(slabs, slab{d, d, turning})
+	}
+	// Planes between the slabs separate them, so a slab further from the
+	// camera's one across those planes can't hide a nearer one
+	camera := dot(c.view(axis), [3]float64{0, 0, c.distance()})
+	at := 0
+	for at < len(slabs) && float64(slabs[at].hi)+0.5 < camera {
+		at++
+	}
+	order := make([]int, len(slabs))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(i, j int) int {
+		return cmp.Compare(abs(j-at), abs(i-at))
+	})
+
+	var out []Polygon
+	for _, i := range order {
+		s := slabs[i]
+		turn := 0.0
+		if s.turning {
+			turn = angle
+		}
+		out = append(out, c.slabPolygons(s, axis, turn)...)
+	}
+	return out
+}
+
+// slabPolygons returns the faces of a slab the camera sees, turned by angle
+// radians about axis, each followed by its stickers
+func (c Camera) slabPolygons(s slab, axis [3]float64, angle float64) []Polygon {
+	// The slab is a box: its bounds along each coordinate
+	var lo, hi [3]float64
+	for i := range axis {
+		lo[i], hi[i] = -1.5, 1.5
+		if axis[i] != 0 {
+			a, b := axis[i]*(float64(s.lo)-0.5), axis[i]*(float64(s.hi)+0.5)
+			lo[i], hi[i] = min(a, b), max(a, b)
+		}
+	}
+	turn := func(p [3]float64) [3]float64 { return rotate(p, axis, angle) }
+
+	var out []Polygon
+	for k := 0; k < 3; k++ {
+		for _, side := range []float64{-1, 1} {
+			var normal, center, half [3]float64
+			normal[k] = side
+			for i := range center {
+				center[i], half[i] = (lo[i]+hi[i])/2, (hi[i]-lo[i])/2
+			}
+			center[k] = hi[k]
+			if side < 0 {
+				center[k] = lo[k]
+			}
+			half[k] = 0
+			if !c.facing(turn(center), turn(normal)) {
+				continue
+			}
+			if math.Abs(center[k]) < 1.5 {
+				// Inside the cube, opened up by the turn
+				out = append(out, c.polygon(cube.Sticker{Index: -1}, true, center, half, turn))
+				continue
+			}
+			f := faceFacing(normal)
+			out = append(out, c.polygon(cube.Sticker{Face: f, Index: 4}, true, center, half, turn))
+			for i := 0; i < 9; i++ {
+				st := cube.Sticker{Face: f, Index: i}
+				pos, _ := st.Position()
+				at := vec(pos, 1)
+				if d := dot(at, axis); d < float64(s.lo) || d > float64(s.hi) {
+					continue
+				}
+				var sh [3]float64
+				for j := range sh {
+					if j != k {
+						sh[j] = stickerHalf
+					}
+				}
+				at[k] = center[k]
+				out = append(out, c.polygon(st, false, at, sh, turn))
+			}
+		}
+	}
+	return out
+}
+
+// Faces returns the faces the camera sees of the cube at rest, farthest
+// first
+func (c Camera) Faces() []cube.Face {
+	type seen struct {
+		face  cube.Face
+		depth float64
+	}
+	var faces []seen
+	for f := cube.Front; f <= cube.Down; f++ {
+		_, normal := cube.Sticker{Face: f, Index: 4}.Position()
+		center := vec(normal, 1.5)
+		if c.facing(center, vec(normal, 1)) {
+			faces = append(faces, seen{f, -c.view(center)[2]})
+		}
+	}
+	slices.SortStableFunc(faces, func(a, b seen) int {
+		return cmp.Compare(b.depth, a.depth)
+	})
+	out := make([]cube.Face, len(faces))
+	for i, s := range faces {
+		out[i] = s.face
+	}
+	return out
+}
+
+// polygon returns the rectangle center ± half, moved by turn, as the camera
+// sees it. half is zero along the rectangle's normal.
+func (c Camera) polygon(s cube.Sticker, body bool, center, half [3]float64, turn func([3]float64) [3]float64) Polygon {
+	var u, v [3]float64 // half the rectangle's sides
+	for i := range half {
+		switch {
+		case half[i] == 0:
+		case u == [3]float64{}:
+			u[i] = half[i]
+		default:
+			v[i] = half[i]
+		}
+	}
+	p := Polygon{Sticker: s, Body: body, Depth: -c.view(turn(center))[2]}
+	for k, d := range [4][2]float64{{-1, -1}, {1, -1}, {1, 1}, {-1, 1}} {
+		var corner [3]float64
+		for i := range corner {
+			corner[i] = center[i] + d[0]*u[i] + d[1]*v[i]
+		}
+		p.Points[k] = c.onPlane(c.view(turn(corner)))
+	}
+	return p
+}
+
+// facing reports whether the camera is on the outer side of the surface at
+// point p with outward normal n
+func (c Camera) facing(p, n [3]float64) bool {
+	pv, nv := c.view(p), c.view(n)
+	if c.Distance <= 0 {
+		return nv[2] > 1e-9
+	}
+	return dot(nv, [3]float64{-pv[0], -pv[1], c.Distance - pv[2]}) > 1e-9
+}
+
+// distance is how far the camera is from the cube's center, with a long
+// way standing in for a parallel projection's infinity
+func (c Camera) distance() float64 {
+	if c.Distance <= 0 {
+		return 1e6
+	}
+	return c.Distance
+}
+
+// faceFacing returns the face with outward normal n
+func faceFacing(n [3]float64) cube.Face {
+	for f := cube.Front; f < cube.Down; f++ {
+		if _, normal := (cube.Sticker{Face: f, Index: 4}).Position(); vec(normal, 1) == n {
+			return f
+		}
+	}
+	return cube.Down
+}
+
+// rotate turns p by angle radians about the unit vector axis, counter
+// clockwise looking down the axis
+func rotate(p, axis [3]float64, angle float64) [3]float64 {
+	if angle == 0 {
+		return p
+	}
+	sin, cos := math.Sincos(angle)
+	cross := [3]float64{
+		axis[1]*p[2] - axis[2]*p[1],
+		axis[2]*p[0] - axis[0]*p[2],
+		axis[0]*p[1] - axis[1]*p[0],
+	}
+	along := dot(axis, p) * (1 - cos)
+	var out [3]float64
+	for i := range out {
+		out[i] = p[i]*cos + cross[i]*sin + axis[i]*along
+	}
+	return out
+}
+
+func dot(a, b [3]float64) float64 {
+	return a[0]*b[0] + a[1]*b[1] + a[2]*b[2]
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// vec returns an integer vector scaled by k
+func vec(v [3]int, k float64) [3]float64 {
+	return [3]float64{k * float64(v[0]), k * float64(v[1]), k * float64(v[2])}
+}
+
+// view turns a point on the cube into the camera's frame, where the camera
+// looks down the z axis towards negative z
+func (c Camera) view(p [3]float64) [3]float64 {
+	sy, cy := math.Sincos(c.Yaw * math.Pi / 180)
+	sp, cp := math.Sincos(c.Pitch * math.Pi / 180)
+	x := p[0]*cy + p[2]*sy
+	z := -p[0]*sy + p[2]*cy
+	return [3]float64{x, p[1]*cp - z*sp, p[1]*sp + z*cp}
+}
+
+// onPlane projects a point in the camera's frame onto the view plane
+func (c Camera) onPlane(p [3]float64) Point {
+	if c.Distance <= 0 {
+		return Point{p[0], p[1]}
+	}
+	k := c.Distance / (c.Distance - p[2])
+	return Point{p[0] * k, p[1] * k}
+}
+
+// radius is how far from the center of the view plane the cube can reach,
+// from any viewpoint
+func (c Camera) radius() float64 {
+	r := 1.5 * math.Sqrt(3)
+	if c.Distance > r {
+		return r * c.Distance / math.Sqrt(c.Distance*c.Distance-r*r)
+	}
+	return r
+}
+
+// contains reports whether q is inside the polygon
+func (p Polygon) contains(q Point) bool {
+	return convexContains(p.Points[:], q)
+}
+
+// convexContains reports whether q is inside the convex polygon points,
+// which may wind either way
+func convexContains(points []Point, q Point) bool {
+	var pos, neg bool
+	for i, a := range points {
+		b := points[(i+1)%len(points)]
+		cross := (b.X-a.X)*(q.Y-a.Y) - (b.Y-a.Y)*(q.X-a.X)
+		pos = pos || cross > 0
+		neg = neg || cross < 0
+	}
+	return !(pos && neg)
+}
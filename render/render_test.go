@@ -0,0 +1,120 @@
+package render
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/michaellavery-grp/rubiks-cube-solver/cube"
+)
+
+func TestFaces(t *testing.T) {
+	tests := []struct {
+		name   string
+		camera Camera
+		want   []cube.Face
+	}{
+		{"front", Camera{}, []cube.Face{cube.Front}},
+		{"perspective", Perspective, []cube.Face{cube.Up, cube.Left, cube.Front}},
+		{"isometric", Isometric, []cube.Face{cube.Up, cube.Right, cube.Front}},
+		{"from behind", Perspective.Turn(180, 0), []cube.Face{cube.Up, cube.Right, cube.Back}},
+		{"from below", Camera{}.Turn(0, -90), []cube.Face{cube.Down}},
+	}
+	for _, tt := range tests {
+		got := tt.camera.Faces()
+		slices.Sort(got)
+		slices.Sort(tt.want)
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: camera sees %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestTurn(t *testing.T) {
+	c := Camera{Yaw: 170, Pitch: 80}.Turn(20, 30)
+	if c.Yaw != -170 || c.Pitch != 90 {
+		t.Errorf("Turn = yaw %g, pitch %g, want -170, 90", c.Yaw, c.Pitch)
+	}
+}
+
+func TestFrameFront(t *testing.T) {
+	// Straight on, the front face is a grid of stickers with the top left
+	// one top left, and nothing else shows
+	frame := Camera{}.Frame(Twist{}, 40, 20)
+	seen := map[cube.Sticker]bool{}
+	first := cube.Sticker{Index: -1}
+	for _, row := range frame {
+		for _, cell := range row {
+			if cell.Kind == Blank {
+				continue
+			}
+			if cell.Sticker.Face != cube.Front {
+				t.Fatalf("a cell shows face %s", cell.Sticker.Face)
+			}
+			if cell.Kind == Colored {
+				seen[cell.Sticker] = true
+				if first.Index < 0 {
+					first = cell.Sticker
+				}
+			}
+		}
+	}
+	if len(seen) != 9 || first.Index != 0 {
+		t.Errorf("front view shows %d stickers starting with %v, want 9 starting with F1", len(seen), first)
+	}
+}
+
+func TestFrameShowsVisibleStickers(t *testing.T) {
+	for _, camera := range []Camera{Perspective, Isometric, Isometric.Turn(135, -60)} {
+		seen := map[cube.Sticker]bool{}
+		for _, row := range camera.Frame(Twist{}, 56, 24) {
+			for _, cell := range row {
+				if cell.Kind == Colored {
+					seen[cell.Sticker] = true
+				}
+			}
+		}
+		for _, f := range camera.Faces() {
+			for i := 0; i < 9; i++ {
+				if !seen[cube.Sticker{Face: f, Index: i}] {
+					t.Errorf("camera %+v doesn't draw %v", camera, cube.Sticker{Face: f, Index: i})
+				}
+			}
+		}
+	}
+}
+
+func TestTwistEndsAsMove(t *testing.T) {
+	// A twist all the way through shows the cube as the move leaves it
+	c := cube.NewCube()
+	c.ApplyMoves(cube.MustParseMoves("R U2 F' L D B2"))
+	for _, m := range cube.MustParseMoves("R U' F2 L' D B M' E S2 Rw x' y z2") {
+		after := c.Clone()
+		after.ApplyMove(m)
+		for _, camera := range []Camera{Perspective, Isometric.Turn(160, -70)} {
+			want := camera.Frame(Twist{}, 56, 24)
+			got := camera.Frame(Twist{m, 1}, 56, 24)
+			for row := range got {
+				for col, cell := range got[row] {
+					w := want[row][col]
+					if cell.Kind != w.Kind || cell.Kind == Colored &&
+						c.Sticker(cell.Sticker.Face, cell.Sticker.Index) != after.Sticker(w.Sticker.Face, w.Sticker.Index) {
+						t.Fatalf("%s from %+v: cell %d,%d is %+v, want %+v", m, camera, row, col, cell, w)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestTwistOpensCube(t *testing.T) {
+	// Half way through R, the inside of the cube shows beside the R layer
+	inside := false
+	for _, row := range Isometric.Frame(Twist{"R", 0.5}, 56, 24) {
+		for _, cell := range row {
+			inside = inside || cell.Kind == Body && cell.Sticker.Index < 0
+		}
+	}
+	if !inside {
+		t.Error("R half way through doesn't show the inside of the cube")
+	}
+}